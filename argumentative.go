@@ -15,56 +15,297 @@
 package argumentative
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
 )
 
 // struct with all maps that hold the different flag types
 type Flags struct {
 	boolflags   map[string]BoolFlag
-	stringflags map[string]StringFlag
-	positionals []Positional
+	countflags  map[string]CountFlag
+	valueflags  map[string]valueFlag
+	positionals []positionalArg
+	commands    []Command
+	order       []string
 
 	shortflags map[byte]string
+	sources    map[string]Source
+	argindexes map[string][]int
+	command    string
+	requirecmd bool
+	rest       []string
+	config     *configFile
+	envprefix  string
+	configflag string
+	pathflags  map[string]bool
+	sorted     bool
+	allerrors  bool
+	distance   int
+	validators map[string][]Validator
+	groups     []flagGroup
+	width      int
+	output     io.Writer
+	erroutput  io.Writer
 }
 
 // constructor like chain command to init all maps
 func (f *Flags) Flags() *Flags {
-	if f.stringflags == nil {
+	if f.valueflags == nil {
 		f.boolflags = make(map[string]BoolFlag)
-		f.stringflags = make(map[string]StringFlag)
+		f.countflags = make(map[string]CountFlag)
+		f.valueflags = make(map[string]valueFlag)
 		f.shortflags = make(map[byte]string)
+		f.sources = make(map[string]Source)
+		f.pathflags = make(map[string]bool)
+		f.distance = defaultDistance
+		f.validators = make(map[string][]Validator)
 	}
 
 	return f
 }
 
-// Add string type flag to map and return pointer to value
-func (f *Flags) AddString(longflag string, shortflag string, required bool, defaultvalue string, description string) *string {
-	f.stringflags[longflag] = NewStringFlag(longflag, shortflag, required, defaultvalue, description)
+// Register a flag that takes a value under its long and short name
+func (f *Flags) addValueFlag(longflag string, shortflag string, flag valueFlag) {
+	f.checkFlag(longflag, shortflag)
+	if flag.env() == "" {
+		flag.setEnv(f.envName(longflag))
+	}
+	f.valueflags[longflag] = flag
+	f.order = append(f.order, longflag)
 	if shortflag != "" {
 		f.shortflags[shortflag[0]] = longflag
 	}
-	return f.stringflags[longflag].Value
+}
+
+// Add string type flag to map and return pointer to value
+func (f *Flags) AddString(longflag string, shortflag string, required bool, defaultvalue string, description string) *string {
+	flag := NewStringFlag(longflag, shortflag, required, defaultvalue, description)
+	f.addValueFlag(longflag, shortflag, &flag)
+	return flag.Value
+}
+
+// Add int type flag to map and return pointer to value
+func (f *Flags) AddInt(longflag string, shortflag string, required bool, defaultvalue int, description string) *int {
+	flag := NewIntFlag(longflag, shortflag, required, defaultvalue, description)
+	f.addValueFlag(longflag, shortflag, &flag)
+	return flag.Value
+}
+
+// Add int64 type flag to map and return pointer to value
+func (f *Flags) AddInt64(longflag string, shortflag string, required bool, defaultvalue int64, description string) *int64 {
+	flag := NewInt64Flag(longflag, shortflag, required, defaultvalue, description)
+	f.addValueFlag(longflag, shortflag, &flag)
+	return flag.Value
+}
+
+// Add uint type flag to map and return pointer to value
+func (f *Flags) AddUint(longflag string, shortflag string, required bool, defaultvalue uint, description string) *uint {
+	flag := NewUintFlag(longflag, shortflag, required, defaultvalue, description)
+	f.addValueFlag(longflag, shortflag, &flag)
+	return flag.Value
+}
+
+// Add float64 type flag to map and return pointer to value
+func (f *Flags) AddFloat64(longflag string, shortflag string, required bool, defaultvalue float64, description string) *float64 {
+	flag := NewFloat64Flag(longflag, shortflag, required, defaultvalue, description)
+	f.addValueFlag(longflag, shortflag, &flag)
+	return flag.Value
+}
+
+// Add flag taking one of the given choices to map and return pointer to value
+func (f *Flags) AddChoice(longflag string, shortflag string, required bool, defaultvalue string, choices []string, description string) *string {
+	checkChoices("--"+longflag, defaultvalue, choices)
+	flag := NewChoiceFlag(longflag, shortflag, required, defaultvalue, choices, description)
+	f.addValueFlag(longflag, shortflag, &flag)
+	return flag.Value
+}
+
+// Add boolean type flag that can be switched off with --no-longflag and return pointer to value
+func (f *Flags) AddNegatableBool(longflag string, shortflag string, defaultvalue bool, description string) *bool {
+	flag := NewBoolFlag(longflag, shortflag, description)
+	flag.Negatable = true
+	flag.Default = defaultvalue
+	*flag.Value = defaultvalue
+	f.addBoolFlag(flag)
+	return flag.Value
+}
+
+// Get the negatable bool flag a name like "no-color" switches off
+func (f *Flags) negation(name string) (BoolFlag, bool) {
+	longflag, ok := strings.CutPrefix(name, "no-")
+	if !ok {
+		return BoolFlag{}, false
+	}
+	flag, ok := f.boolflags[longflag]
+	return flag, ok && flag.Negatable
 }
 
 // Add boolean type flag to map and return pointer to value
 func (f *Flags) AddBool(longflag string, shortflag string, description string) *bool {
-	f.boolflags[longflag] = NewBoolFlag(longflag, shortflag, description)
-	if shortflag != "" {
-		f.shortflags[shortflag[0]] = longflag
+	flag := NewBoolFlag(longflag, shortflag, description)
+	f.addBoolFlag(flag)
+	return flag.Value
+}
+
+// Register a bool flag with its short name
+func (f *Flags) addBoolFlag(flag BoolFlag) {
+	f.checkFlag(flag.Longflag, flag.Shortflag)
+	if flag.Negatable && f.hasFlag("no-"+flag.Longflag) {
+		panic("argumentative: negation --no-" + flag.Longflag + " of --" + flag.Longflag + " is already a flag")
+	}
+	if flag.Env == "" {
+		flag.Env = f.envName(flag.Longflag)
+	}
+	f.boolflags[flag.Longflag] = flag
+	f.order = append(f.order, flag.Longflag)
+	if flag.Shortflag != "" {
+		f.shortflags[flag.Shortflag[0]] = flag.Longflag
+	}
+}
+
+// Add repeatable string type flag to map and return pointer to the collected values
+func (f *Flags) AddStringSlice(longflag string, shortflag string, required bool, defaultvalue []string, description string) *[]string {
+	flag := NewStringSliceFlag(longflag, shortflag, required, defaultvalue, description)
+	f.addValueFlag(longflag, shortflag, &flag)
+	return flag.Value
+}
+
+// Add repeatable int type flag to map and return pointer to the collected values
+func (f *Flags) AddIntSlice(longflag string, shortflag string, required bool, defaultvalue []int, description string) *[]int {
+	flag := NewIntSliceFlag(longflag, shortflag, required, defaultvalue, description)
+	f.addValueFlag(longflag, shortflag, &flag)
+	return flag.Value
+}
+
+// Split every value of a repeatable flag by separator, like "-I a,b"
+func (f *Flags) SetSeparator(longflag string, separator string) {
+	flag, ok := f.valueflags[longflag].(sliceFlag)
+	if !ok {
+		panic("argumentative: --" + longflag + " is not a repeatable flag")
+	}
+	flag.setSeparator(separator)
+}
+
+// Add counting flag to map and return pointer to the number of occurrences
+func (f *Flags) AddCount(longflag string, shortflag string, maximum int, description string) *int {
+	flag := NewCountFlag(longflag, shortflag, maximum, description)
+	f.addCountFlag(flag)
+	return flag.Value
+}
+
+// Register a count flag with its short name
+func (f *Flags) addCountFlag(flag CountFlag) {
+	f.checkFlag(flag.Longflag, flag.Shortflag)
+	if flag.Env == "" {
+		flag.Env = f.envName(flag.Longflag)
+	}
+	f.countflags[flag.Longflag] = flag
+	f.order = append(f.order, flag.Longflag)
+	if flag.Shortflag != "" {
+		f.shortflags[flag.Shortflag[0]] = flag.Longflag
 	}
-	return f.boolflags[longflag].Value
 }
 
 // Add positional argument to map and return pointer to value
 func (f *Flags) AddPositional(longflag string, required bool, defaultvalue string, description string) *string {
-	f.positionals = append(f.positionals, NewPositional(longflag, required, defaultvalue, description))
-	return f.positionals[len(f.positionals)-1].Value
+	positional := NewPositional(longflag, required, defaultvalue, description)
+	f.addPositional(&positional)
+	return positional.Value
+}
+
+// Add positional argument taking one of the given choices and return pointer to value
+func (f *Flags) AddPositionalChoice(longflag string, required bool, defaultvalue string, choices []string, description string) *string {
+	checkChoices("["+longflag+"]", defaultvalue, choices)
+	positional := NewPositional(longflag, required, defaultvalue, description)
+	positional.Choices = choices
+	f.addPositional(&positional)
+	return positional.Value
+}
+
+// Accept the choices of a choice flag or positional argument in any case
+func (f *Flags) SetIgnoreCase(name string) {
+	if flag, ok := f.valueflags[name].(*ChoiceFlag); ok {
+		flag.IgnoreCase = true
+		return
+	}
+	for _, positional := range f.positionals {
+		if positional, ok := positional.(*Positional); ok && positional.Longflag == name && len(positional.Choices) > 0 {
+			positional.IgnoreCase = true
+			return
+		}
+	}
+	panic("argumentative: " + name + " is neither a choice flag nor a positional argument with choices")
+}
+
+// Check that there are choices and the default is one of them
+func checkChoices(name string, defaultvalue string, choices []string) {
+	if len(choices) == 0 {
+		panic("argumentative: no choices for " + name)
+	}
+	if _, err := matchChoice(defaultvalue, choices, false); defaultvalue != "" && err != nil {
+		panic("argumentative: default " + defaultvalue + " of " + name + " is not one of " + strings.Join(choices, ", "))
+	}
+}
+
+// Add positional argument taking several values, nargs is "?", "*", "+" or a number like "2"
+func (f *Flags) AddPositionalList(longflag string, nargs string, defaultvalue []string, description string) *[]string {
+	positional := NewPositionalList(longflag, nargs, defaultvalue, description)
+	f.addPositional(&positional)
+	return positional.Value
 }
 
-// Check if argument is a flag or positional argument
+// Add subcommand and return its own set of flags, it takes over the settings of f
+func (f *Flags) AddCommand(name string, description string) *Flags {
+	f.checkCommand(name)
+	command := NewCommand(name, description)
+	// Subcommands share the settings of their parent
+	command.Flags.allerrors = f.allerrors
+	command.Flags.distance = f.distance
+	command.Flags.envprefix = f.envprefix
+	f.commands = append(f.commands, command)
+	return command.Flags
+}
+
+// Make choosing one of the subcommands required, by default the flags of f work on their own
+func (f *Flags) SetCommandRequired(required bool) {
+	f.requirecmd = required
+}
+
+// Get the name of the subcommand chosen by the last call of Parse
+func (f *Flags) Command() string {
+	return f.command
+}
+
+// Get the arguments after "--" that were not taken by positional arguments
+func (f *Flags) Rest() []string {
+	return f.rest
+}
+
+// Find a subcommand by name
+func (f *Flags) getCommand(name string) *Command {
+	for i := range f.commands {
+		if f.commands[i].Name == name {
+			return &f.commands[i]
+		}
+	}
+	return nil
+}
+
+// Check if a flag of any kind is defined with this long name
+func (f *Flags) hasFlag(name string) bool {
+	_, isValue := f.valueflags[name]
+	_, isBool := f.boolflags[name]
+	_, isCount := f.countflags[name]
+	return isValue || isBool || isCount
+}
+
+// Check if argument is a flag or positional argument, an empty argument and a lone "-" for stdin are positional
 func (f *Flags) isFlag(name string) bool {
-	return name[0] == '-'
+	return len(name) > 1 && name[0] == '-'
 }
 
 // Check if argument is a long flag
@@ -72,13 +313,13 @@ func (f *Flags) isLongFlag(name string) bool {
 	return len(name) > 1 && name[0] == '-' && name[1] == '-'
 }
 
-// Get name of flag and translate short flags to their long equivalent
+// Get name of flag and translate short flags to their long equivalent, "--name=value" returns "name"
 func (f *Flags) GetFlagName(name string, pos int) string {
 	var longname string
 
 	if len(name) > 1 && name[0] == '-' {
 		if len(name) > 2 && name[1] == '-' {
-			longname = name[2:]
+			longname, _, _ = strings.Cut(name[2:], "=")
 		} else {
 			if pos < len(name) {
 				longname = f.shortflags[name[pos]]
@@ -91,112 +332,380 @@ func (f *Flags) GetFlagName(name string, pos int) string {
 	return ""
 }
 
+// Assign a value to a flag that takes one
+func (f *Flags) setValue(flag valueFlag, name string, value string, index int) error {
+	// The first occurrence replaces the defaults of repeatable flags
+	if slice, ok := flag.(sliceFlag); ok && !f.IsSet(name) {
+		slice.clear()
+	}
+	if err := flag.set(value); err != nil {
+		return &InvalidValueError{Flag: name, Value: value, Index: index, Err: err}
+	}
+	if err := f.runValidators(name, value, flag.value()); err != nil {
+		return &InvalidValueError{Flag: name, Value: value, Index: index, Err: err}
+	}
+	f.sources[name] = SourceCLI
+	return nil
+}
+
+// Switch a bool flag on or count a count flag, report if name is a switch at all
+func (f *Flags) setSwitch(name string) bool {
+	if flag, ok := f.boolflags[name]; ok {
+		*flag.Value = true
+		f.sources[name] = SourceCLI
+		return true
+	}
+	if flag, ok := f.countflags[name]; ok {
+		// The first occurrence restarts counting from zero
+		if !f.IsSet(name) {
+			*flag.Value = 0
+		}
+		flag.increment()
+		f.sources[name] = SourceCLI
+		return true
+	}
+	return false
+}
+
+// Distribute the values over the positionals in order, each takes as many as it can
+// while leaving enough values for the minimum of all positionals after it
+func (f *Flags) assignPositionals(values []string, indexes []int, passed int) error {
+	required := make([]int, len(f.positionals)+1)
+	for p := len(f.positionals) - 1; p >= 0; p-- {
+		min, _ := f.positionals[p].bounds()
+		required[p] = required[p+1] + min
+	}
+
+	for p, positional := range f.positionals {
+		min, max := positional.bounds()
+		take := len(values) - required[p+1]
+		if max >= 0 && take > max {
+			take = max
+		}
+		if take < min {
+			take = min
+		}
+		if take > len(values) {
+			take = len(values)
+		}
+		if take > 0 {
+			positional.assign(values[:take])
+			f.sources[positional.info().name] = SourceCLI
+			f.argindexes[positional.info().name] = indexes[:take]
+			values = values[take:]
+			indexes = indexes[take:]
+		}
+	}
+
+	// Values left over are only allowed after "--"
+	if len(values) > passed {
+		return &TooManyPositionalsError{Value: values[0], Index: indexes[0]}
+	}
+	f.rest = values
+	return nil
+}
+
+// Get the index of the i-th value of a positional argument in the arguments of Parse, -1 for defaults
+func (f *Flags) positionalIndex(name string, i int) int {
+	if i < len(f.argindexes[name]) {
+		return f.argindexes[name][i]
+	}
+	return -1
+}
+
+// Fill the flags not given on the command line from environment and config file, then validate
+func (f *Flags) complete() error {
+	errs := &errorList{all: f.allerrors}
+	if !errs.add(f.applyEnv()) && !errs.add(f.applyConfig()) {
+		errs.add(f.Validate())
+	}
+	return errs.err()
+}
+
 // Validate the parameters and check if all required parameters have a value
 func (f *Flags) Validate() (err error) {
-	for _, flag := range f.stringflags {
-		if flag.Required && *flag.Value == "" {
-			return fmt.Errorf("required flag --%s missing", flag.Longflag)
+	errs := &errorList{all: f.allerrors}
+	for _, name := range f.order {
+		if flag, ok := f.valueflags[name]; ok && flag.isMissing(f.IsSet(name)) {
+			if errs.add(&MissingRequiredError{Kind: "flag", Name: name}) {
+				return errs.err()
+			}
+		}
+	}
+	for _, err := range f.validateGroups() {
+		if errs.add(err) {
+			return errs.err()
 		}
 	}
 	for _, positional := range f.positionals {
-		if positional.Required && *positional.Value == "" {
-			return fmt.Errorf("required positional argument [%s] missing", positional.Longflag)
+		name := positional.info().name
+		err := positional.validate()
+		var invalid *InvalidValueError
+		if errors.As(err, &invalid) {
+			invalid.Index = f.positionalIndex(name, 0)
+		}
+		if errs.add(err) {
+			return errs.err()
+		}
+		// Like flags, only values that were given are validated, not the defaults
+		if !f.IsSet(name) {
+			continue
+		}
+		for i, value := range positional.values() {
+			if err := f.runValidators(name, value, value); err != nil {
+				if errs.add(&InvalidValueError{Flag: name, Value: value, Index: f.positionalIndex(name, i), Positional: true, Err: err}) {
+					return errs.err()
+				}
+				break
+			}
 		}
 	}
-	return nil
+	// Switches have no value of their own to check when they are set, their result is checked
+	for _, name := range f.order {
+		if !f.IsSet(name) || len(f.validators[name]) == 0 {
+			continue
+		}
+		var raw string
+		var value interface{}
+		if flag, ok := f.boolflags[name]; ok {
+			raw, value = strconv.FormatBool(*flag.Value), *flag.Value
+		} else if flag, ok := f.countflags[name]; ok {
+			raw, value = strconv.Itoa(*flag.Value), *flag.Value
+		} else {
+			continue
+		}
+		if err := f.runValidators(name, raw, value); err != nil {
+			if errs.add(&InvalidValueError{Flag: name, Value: raw, Index: -1, Err: err}) {
+				return errs.err()
+			}
+		}
+	}
+	if f.requirecmd && len(f.commands) > 0 && f.command == "" {
+		errs.add(&MissingRequiredError{Kind: "command"})
+	}
+	return errs.err()
 }
 
 // Parse arguments
 func (f *Flags) Parse(args []string) (err error) {
-	positional := 0
+	return f.parse(args, 0, nil)
+}
+
+// Parse arguments, offset is the index of args[0] in the arguments of the top level Parse
+func (f *Flags) parse(args []string, offset int, config *configFile) error {
+	f.Reset()
+	f.config = config
+	errs := &errorList{all: f.allerrors}
+	var values []string
+	// index of every value in the arguments for error reporting
+	var indexes []int
+	// number of values at the end of values that were given after "--"
+	passed := 0
 	i := 1 // leave out the first one as this is usually the (cli-) command itself
 	for i < len(args) {
-		if f.isFlag(args[i]) {
-			// Parse flags with string values
-			if _, ok := f.stringflags[f.GetFlagName(args[i], 1)]; ok {
-				if !f.Flags().isLongFlag(args[i]) && len(args[i]) > 2 {
-					return fmt.Errorf("options with parameters can not be combined %s", args[i])
+		if args[i] == "--" {
+			// End of options, the remaining arguments fill the positionals and the rest is kept as is
+			for j := i + 1; j < len(args); j++ {
+				values = append(values, args[j])
+				indexes = append(indexes, offset+j)
+			}
+			passed = len(args) - i - 1
+			break
+		} else if f.isLongFlag(args[i]) {
+			// Parse long flags, the value is either attached with "=" or the next argument
+			name := f.GetFlagName(args[i], 1)
+			_, value, attached := strings.Cut(args[i], "=")
+			index := offset + i
+			if flag, ok := f.valueflags[name]; ok {
+				if !attached {
+					if i+1 >= len(args) {
+						errs.add(&MissingValueError{Flag: name, Index: index})
+						return errs.err()
+					}
+					value = args[i+1]
+					i += 1
 				}
-				*f.stringflags[f.GetFlagName(args[i], 1)].Value = args[i+1]
-				i += 1
-			} else {
-				// Parse flags the switch to true if exists, allow "-xvzf" as combinations
-				if f.isLongFlag(args[i]) {
-					if _, ok := f.boolflags[f.GetFlagName(args[i], 1)]; ok {
-						*f.boolflags[f.GetFlagName(args[i], 1)].Value = true
-					} else {
-						return fmt.Errorf("unknown flag %s", args[i])
+				if errs.add(f.setValue(flag, name, value, index)) {
+					return errs.err()
+				}
+			} else if flag, ok := f.boolflags[name]; ok && attached {
+				converted, err := parseBool(value)
+				if err != nil {
+					if errs.add(&InvalidValueError{Flag: name, Value: value, Index: index, Err: conversionError(err, "boolean")}) {
+						return errs.err()
+					}
+				} else {
+					*flag.Value = converted
+					f.sources[name] = SourceCLI
+				}
+			} else if _, ok := f.countflags[name]; ok && attached {
+				if errs.add(&InvalidValueError{Flag: name, Value: value, Index: index, Err: errNoValue}) {
+					return errs.err()
+				}
+			} else if flag, ok := f.negation(name); ok {
+				if attached {
+					if errs.add(&InvalidValueError{Flag: name, Value: value, Index: index, Err: errNoValue}) {
+						return errs.err()
 					}
 				} else {
-					for j := 1; j < len(args[i]); j++ {
-						if _, ok := f.boolflags[f.GetFlagName(args[i], j)]; ok {
-							*f.boolflags[f.GetFlagName(args[i], j)].Value = true
-						} else {
-							if _, ok := f.stringflags[f.GetFlagName(args[i], j)]; ok {
-								return fmt.Errorf("options with parameters can not be combined: %c in %s", args[i][j], args[i])
-							} else {
-								return fmt.Errorf("unknown flag -%c", args[i][j])
-							}
-						}
+					*flag.Value = false
+					f.sources[flag.Longflag] = SourceCLI
+				}
+			} else if !f.setSwitch(name) {
+				if errs.add(&UnknownFlagError{Flag: args[i], Index: index, Suggestions: f.suggestFlags(name)}) {
+					return errs.err()
+				}
+			}
+		} else if f.isFlag(args[i]) {
+			// Parse short flags that switch to true or count, allow "-xvzf" as combinations
+			index := offset + i
+			var suggestions []string
+			suggested := false
+			for j := 1; j < len(args[i]); j++ {
+				name := f.GetFlagName(args[i], j)
+				if f.setSwitch(name) {
+					continue
+				}
+				flag, ok := f.valueflags[name]
+				if !ok {
+					// The whole argument is compared, "-verbose" was probably meant as long flag,
+					// once for all unknown letters of the argument
+					if !suggested {
+						suggestions = f.suggestFlags(args[i][1:])
+						suggested = true
 					}
+					unknown := &UnknownFlagError{Flag: "-" + string(args[i][j]), Index: index, Suggestions: suggestions}
+					if errs.add(unknown) {
+						return errs.err()
+					}
+					continue
+				}
+				// A flag with value takes the rest of the argument like "-ofile" or the next one
+				value := args[i][j+1:]
+				if value == "" {
+					if i+1 >= len(args) {
+						errs.add(&MissingValueError{Flag: name, Index: index})
+						return errs.err()
+					}
+					value = args[i+1]
+					i += 1
 				}
+				if errs.add(f.setValue(flag, name, value, index)) {
+					return errs.err()
+				}
+				break
+			}
+			// Hand over all remaining arguments to the chosen subcommand
+		} else if len(f.commands) > 0 {
+			command := f.getCommand(args[i])
+			if command == nil {
+				errs.add(&UnknownCommandError{Command: args[i], Index: offset + i, Suggestions: f.suggestCommands(args[i])})
+				return errs.err()
 			}
-			// Parse positional arguments sequentially while there are unset ones
-		} else if positional < len(f.positionals) {
-			*f.positionals[positional].Value = args[i]
-			positional += 1
+			f.command = command.Name
+			if errs.add(f.assignPositionals(values, indexes, passed)) || errs.add(f.complete()) {
+				return errs.err()
+			}
+			errs.add(command.Flags.parse(args[i:], offset+i, f.config.section(command.Name)))
+			return errs.err()
+			// Collect positional arguments, they are distributed when all are known
 		} else {
-			return fmt.Errorf("unknown positional argument %s", args[i])
+			values = append(values, args[i])
+			indexes = append(indexes, offset+i)
 		}
 		i += 1
 	}
-	return f.Validate()
+	if !errs.add(f.assignPositionals(values, indexes, passed)) {
+		errs.add(f.complete())
+	}
+	return errs.err()
 }
 
-// Print usage instructions
+// Set the writer for the help text, default is stdout
+func (f *Flags) SetOutput(w io.Writer) {
+	f.output = w
+}
+
+// Set the writer for the help text with an error, default is stderr
+func (f *Flags) SetErrorOutput(w io.Writer) {
+	f.erroutput = w
+}
+
+// List flags sorted alphabetically instead of the order they were added in
+func (f *Flags) SetSorted(sorted bool) {
+	f.sorted = sorted
+}
+
+// Print usage instructions, with an error to the error output
 func (f *Flags) Usage(name string, description string, err error) {
-	if err != nil {
-		fmt.Println("Error:", err)
-	} else {
-		fmt.Println(name)
-		fmt.Println(description)
+	w := f.output
+	if w == nil {
+		w = os.Stdout
 	}
-	output := "\nUsage: " + name
-	if len(f.stringflags) > 0 || len(f.boolflags) > 0 {
-		for _, flag := range f.boolflags {
-			output += flag.GetShortDescription()
-		}
-		for _, flag := range f.stringflags {
-			output += flag.GetShortDescription()
+	if err != nil {
+		w = f.erroutput
+		if w == nil {
+			w = os.Stderr
 		}
 	}
-	if len(f.positionals) > 0 {
-		for _, positional := range f.positionals {
-			output += positional.GetShortDescription()
-		}
+	fmt.Fprint(w, f.UsageString(name, description, err))
+}
+
+// Generate the usage instructions printed by Usage
+func (f *Flags) UsageString(name string, description string, err error) string {
+	var b strings.Builder
+	if err != nil {
+		fmt.Fprintln(&b, "Error:", err)
+	} else {
+		fmt.Fprintln(&b, name)
+		fmt.Fprintln(&b, description)
 	}
+	width := f.helpWidth()
+	fmt.Fprintln(&b, "\n"+f.usageLine(name, width))
 
-	fmt.Println(output)
+	infos := f.flagInfos()
+	positionals := f.positionalInfos()
+	var names []string
+	for _, info := range infos {
+		names = append(names, flagNames(info))
+	}
+	for _, positional := range positionals {
+		names = append(names, positionalNames(positional))
+	}
+	for _, command := range f.commands {
+		names = append(names, command.Name)
+	}
+	column := helpColumn(names)
 
-	if len(f.boolflags) > 0 {
-		fmt.Println("\nFlags:")
-		for _, flag := range f.boolflags {
-			fmt.Println(flag.GetLongDescription())
+	flags := ""
+	options := ""
+	for _, info := range infos {
+		entry := formatEntry(flagNames(info), flagText(info), column, width) + "\n"
+		if info.takesValue {
+			options += entry
+		} else {
+			flags += entry
 		}
 	}
+	if flags != "" {
+		fmt.Fprint(&b, "\nFlags:\n", flags)
+	}
+	if options != "" {
+		fmt.Fprint(&b, "\nOptions:\n", options)
+	}
 
-	if len(f.stringflags) > 0 {
-		fmt.Println("\nOptions:")
-		for _, flag := range f.stringflags {
-			fmt.Println(flag.GetLongDescription())
+	if len(positionals) > 0 {
+		fmt.Fprintln(&b, "\nPositional arguments:")
+		for _, positional := range positionals {
+			fmt.Fprintln(&b, formatEntry(positionalNames(positional), positionalText(positional), column, width))
 		}
 	}
 
-	if len(f.positionals) > 0 {
-		fmt.Println("\nPositional arguments:")
-		for _, positional := range f.positionals {
-			fmt.Println(positional.GetLongDescription())
+	if len(f.commands) > 0 {
+		fmt.Fprintln(&b, "\nCommands:")
+		for _, command := range f.commands {
+			fmt.Fprintln(&b, formatEntry(command.Name, command.Description, column, width))
 		}
 	}
-
+	return b.String()
 }
@@ -0,0 +1,83 @@
+package argumentative
+
+import (
+	"strings"
+	"testing"
+)
+
+// Build flags using every kind of flag, positional argument and check, fresh for every input
+func fuzzFlags() *Flags {
+	flags := &Flags{}
+	flags.Flags().AddBool("verbose", "v", "Verbose output")
+	flags.Flags().AddNegatableBool("color", "", true, "Colored output")
+	flags.Flags().AddCount("debug", "d", 3, "Debug level")
+	flags.Flags().AddString("output", "o", false, "", "Output file")
+	flags.Flags().AddInt("port", "p", false, 80, "Port")
+	flags.Flags().AddInt64("size", "", false, 0, "Size")
+	flags.Flags().AddUint("workers", "w", false, 1, "Workers")
+	flags.Flags().AddFloat64("ratio", "r", false, 0.5, "Ratio")
+	flags.Flags().AddStringSlice("include", "I", false, nil, "Include path")
+	flags.Flags().AddIntSlice("ids", "", false, nil, "IDs")
+	flags.Flags().AddChoice("format", "f", false, "table", []string{"json", "yaml", "table"}, "Format")
+	flags.Flags().AddBool("json", "j", "JSON output")
+	flags.Flags().AddExclusiveGroup("json", "format")
+	flags.SetSeparator("include", ",")
+	flags.AddValidator("output", NotEmpty(), LengthBetween(1, 20))
+	deploy := flags.Flags().AddCommand("deploy", "Deploy a service")
+	deploy.AddString("env", "e", true, "", "Target environment")
+	deploy.AddPositionalChoice("mode", false, "", []string{"start", "stop"}, "Mode")
+	deploy.AddPositional("source", false, "", "Source")
+	deploy.AddPositionalList("targets", "*", nil, "Targets")
+	return flags
+}
+
+func FuzzParse(f *testing.F) {
+	seeds := []string{
+		"",
+		"-",
+		"--",
+		"--test",
+		"-o",
+		"--output",
+		"--output=",
+		"-vvvd",
+		"-ofile",
+		"--port\n-1",
+		"--port\n99999999999999999999",
+		"--no-color",
+		"--no-verbose",
+		"--color=maybe",
+		"--debug=2",
+		"-I\na,b,,c",
+		"--ids\nx",
+		"--format=XML",
+		"--json\n--format\njson",
+		"deploy",
+		"deploy\n-e",
+		"deploy\n--env\nprod\nrestart",
+		"delpoy",
+		"--\n-v\n--port",
+		"deploy\n-e\nx\na\nb\nc\n--\nd",
+		"\n\n",
+		"-\x00",
+		"--=",
+		"---",
+		"-é",
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		// Every line of the input is one argument
+		args := append([]string{"tool"}, strings.Split(input, "\n")...)
+		for _, all := range []bool{false, true} {
+			flags := fuzzFlags()
+			flags.SetAllErrors(all)
+			err := flags.Parse(args)
+			if err != nil && err.Error() == "" {
+				t.Errorf("Error without message for %q", args)
+			}
+			flags.UsageString("tool", "description", err)
+		}
+	})
+}
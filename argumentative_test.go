@@ -2,9 +2,11 @@ package argumentative
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"log"
 	"os"
+	"reflect"
 	"sync"
 	"testing"
 )
@@ -111,7 +113,60 @@ func TestFlagsIntegration(t *testing.T) {
 
 	args = append(args, "-sz")
 	err = flags.Parse(args)
-	await = "options with parameters can not be combined -sz"
+
+	if err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if *stringflag != "z" {
+		t.Errorf("Wrong attached stringflag value, got [%s], want [%s]", *stringflag, "z")
+	}
+
+	if *optz {
+		t.Errorf("Wrong combined boolflag value, got [%t], want [%t]", *optz, false)
+	}
+
+	args = append(args, "-zsvalue")
+	err = flags.Parse(args)
+
+	if err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if *stringflag != "value" || !*optz {
+		t.Errorf("Wrong combined values, got [%s %t], want [%s %t]", *stringflag, *optz, "value", true)
+	}
+}
+
+func TestAttachedValues(t *testing.T) {
+	flags := &Flags{}
+	output := flags.Flags().AddString("output", "o", false, "", "Output file")
+	offset := flags.Flags().AddInt("offset", "", false, 0, "Offset")
+	color := flags.Flags().AddBool("color", "c", "Colored output")
+	verbose := flags.Flags().AddCount("verbose", "v", 0, "Verbosity")
+
+	err := flags.Parse([]string{"scriptname", "--output=-", "--offset=-5", "--color=true"})
+
+	if err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if *output != "-" || *offset != -5 || !*color {
+		t.Errorf("Wrong attached values, got [%s %d %t], want [%s %d %t]", *output, *offset, *color, "-", -5, true)
+	}
+
+	err = flags.Parse([]string{"scriptname", "-ofile", "--output=", "--color=false"})
+
+	if err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if *output != "" || *color {
+		t.Errorf("Wrong attached values, got [%s %t], want [%s %t]", *output, *color, "", false)
+	}
+
+	err = flags.Parse([]string{"scriptname", "--color=maybe"})
+	await := "invalid value \"maybe\" for --color: expected boolean"
 
 	if err == nil {
 		t.Errorf("No error found, got [%p], want pointer", err)
@@ -119,8 +174,388 @@ func TestFlagsIntegration(t *testing.T) {
 		t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
 	}
 
-	if *optz {
-		t.Errorf("Wrong combined boolflag value, got [%t], want [%t]", *optz, false)
+	err = flags.Parse([]string{"scriptname", "--verbose=2"})
+	await = `invalid value "2" for --verbose: flag does not take a value`
+
+	if err == nil {
+		t.Errorf("No error found, got [%p], want pointer", err)
+	} else if err.Error() != await {
+		t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
+	}
+
+	if *verbose != 0 {
+		t.Errorf("Wrong count value, got [%d], want [%d]", *verbose, 0)
+	}
+}
+
+func TestNumericFlags(t *testing.T) {
+	flags := &Flags{}
+	port := flags.Flags().AddInt("port", "p", true, 0, "Port to listen on")
+	size := flags.Flags().AddInt64("size", "", false, 1024, "Maximum size")
+	workers := flags.Flags().AddUint("workers", "w", false, 4, "Number of workers")
+	ratio := flags.Flags().AddFloat64("ratio", "r", false, 0.5, "Sampling ratio")
+
+	err := flags.Parse([]string{"scriptname"})
+	await := "required flag --port missing"
+
+	if err == nil {
+		t.Errorf("No error found, got [%p], want pointer", err)
+	} else if err.Error() != await {
+		t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
+	}
+
+	if *size != 1024 || *workers != 4 || *ratio != 0.5 {
+		t.Errorf("Wrong default values, got [%d %d %g], want [%d %d %g]", *size, *workers, *ratio, 1024, 4, 0.5)
+	}
+
+	err = flags.Parse([]string{"scriptname", "-p", "0", "--size", "-1", "-w", "16", "-r", "0.125"})
+
+	if err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if *port != 0 || *size != -1 || *workers != 16 || *ratio != 0.125 {
+		t.Errorf("Wrong values, got [%d %d %d %g], want [%d %d %d %g]", *port, *size, *workers, *ratio, 0, -1, 16, 0.125)
+	}
+
+	err = flags.Parse([]string{"scriptname", "--port", "abc"})
+	await = "invalid value \"abc\" for --port: expected integer"
+
+	if err == nil {
+		t.Errorf("No error found, got [%p], want pointer", err)
+	} else if err.Error() != await {
+		t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
+	}
+}
+
+func TestSliceFlags(t *testing.T) {
+	flags := &Flags{}
+	includes := flags.Flags().AddStringSlice("include", "I", false, []string{"/usr/include"}, "Include path")
+	tags := flags.Flags().AddStringSlice("tag", "t", false, nil, "Build tags")
+	ports := flags.Flags().AddIntSlice("port", "p", false, nil, "Ports")
+	flags.Flags().SetSeparator("tag", ",")
+
+	err := flags.Parse([]string{"scriptname"})
+
+	if err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if !reflect.DeepEqual(*includes, []string{"/usr/include"}) {
+		t.Errorf("Wrong default values, got [%v], want [%v]", *includes, []string{"/usr/include"})
+	}
+
+	err = flags.Parse([]string{"scriptname", "-I", "a", "--include", "b", "-t", "x,y", "-p", "80", "-p", "443"})
+
+	if err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if !reflect.DeepEqual(*includes, []string{"a", "b"}) {
+		t.Errorf("Wrong collected values, got [%v], want [%v]", *includes, []string{"a", "b"})
+	}
+
+	if !reflect.DeepEqual(*tags, []string{"x", "y"}) {
+		t.Errorf("Wrong split values, got [%v], want [%v]", *tags, []string{"x", "y"})
+	}
+
+	if !reflect.DeepEqual(*ports, []int{80, 443}) {
+		t.Errorf("Wrong collected values, got [%v], want [%v]", *ports, []int{80, 443})
+	}
+
+	err = flags.Parse([]string{"scriptname", "-p", "80"})
+
+	if err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if !reflect.DeepEqual(*ports, []int{80}) {
+		t.Errorf("Values of previous parse were kept, got [%v], want [%v]", *ports, []int{80})
+	}
+}
+
+func TestCountFlags(t *testing.T) {
+	flags := &Flags{}
+	verbose := flags.Flags().AddCount("verbose", "v", 0, "Increase verbosity")
+	quiet := flags.Flags().AddCount("quiet", "q", 2, "Decrease verbosity")
+	flags.Flags().AddBool("all", "a", "All")
+
+	err := flags.Parse([]string{"scriptname", "-vvv", "--verbose", "-avq", "-qqq"})
+
+	if err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if *verbose != 5 {
+		t.Errorf("Wrong count value, got [%d], want [%d]", *verbose, 5)
+	}
+
+	if *quiet != 2 {
+		t.Errorf("Wrong count value with maximum, got [%d], want [%d]", *quiet, 2)
+	}
+
+	err = flags.Parse([]string{"scriptname", "-v"})
+
+	if err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if *verbose != 1 {
+		t.Errorf("Count of previous parse was kept, got [%d], want [%d]", *verbose, 1)
+	}
+}
+
+func TestCommands(t *testing.T) {
+	flags := &Flags{}
+	verbose := flags.Flags().AddBool("verbose", "v", "Verbose output")
+	deploy := flags.Flags().AddCommand("deploy", "Deploy a service")
+	env := deploy.AddString("env", "e", true, "", "Target environment")
+	service := deploy.AddPositional("service", true, "", "Service to deploy")
+	status := flags.Flags().AddCommand("status", "Show status")
+	status.AddString("format", "f", true, "", "Output format")
+
+	err := flags.Parse([]string{"tool", "deploy", "svc"})
+	await := "required flag --env missing"
+
+	if err == nil {
+		t.Errorf("No error found, got [%p], want pointer", err)
+	} else if err.Error() != await {
+		t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
+	}
+
+	err = flags.Parse([]string{"tool", "-v", "deploy", "--env", "prod", "svc"})
+
+	if err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if flags.Command() != "deploy" {
+		t.Errorf("Wrong command chosen, got [%s], want [%s]", flags.Command(), "deploy")
+	}
+
+	if !*verbose || *env != "prod" || *service != "svc" {
+		t.Errorf("Wrong values, got [%t %s %s], want [%t %s %s]", *verbose, *env, *service, true, "prod", "svc")
+	}
+
+	flags.SetCommandRequired(true)
+	err = flags.Parse([]string{"tool", "-v"})
+	await = "required command missing"
+
+	if err == nil {
+		t.Errorf("No error found, got [%p], want pointer", err)
+	} else if err.Error() != await {
+		t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
+	}
+
+	err = flags.Parse([]string{"tool", "destroy"})
+	await = "unknown command destroy"
+
+	if err == nil {
+		t.Errorf("No error found, got [%p], want pointer", err)
+	} else if err.Error() != await {
+		t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
+	}
+
+	err = flags.Parse([]string{"tool", "deploy", "-v", "svc"})
+	await = "unknown flag -v"
+
+	if err == nil {
+		t.Errorf("No error found, got [%p], want pointer", err)
+	} else if err.Error() != await {
+		t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
+	}
+}
+
+func TestCommandOptional(t *testing.T) {
+	flags := &Flags{}
+	version := flags.Flags().AddBool("version", "", "Show the version")
+	deploy := flags.Flags().AddCommand("deploy", "Deploy a service")
+	deploy.AddString("env", "e", true, "", "Target environment")
+
+	err := flags.Parse([]string{"tool", "--version"})
+
+	if err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if !*version {
+		t.Errorf("Wrong value, got [%t], want [%t]", *version, true)
+	}
+
+	if flags.Command() != "" {
+		t.Errorf("Command chosen, got [%s], want [%s]", flags.Command(), "")
+	}
+}
+
+func TestCommandUsage(t *testing.T) {
+	flags := &Flags{}
+	flags.Flags().AddBool("verbose", "v", "Verbose output")
+	flags.Flags().AddCommand("deploy", "Deploy a service")
+	flags.Flags().AddCommand("status", "Show status")
+
+	await := `tool
+description
+
+Usage: tool [-v] [command ...]
+
+Flags:
+-v, --verbose            Verbose output
+
+Commands:
+deploy                   Deploy a service
+status                   Show status
+`
+
+	result := captureOutput(func() {
+		flags.Usage("tool", "description", nil)
+	})
+
+	if result != await {
+		t.Errorf("Wrong Usage output, got\n%s\n\nwant\n\n%s", result, await)
+	}
+}
+
+func TestEndOfOptions(t *testing.T) {
+	flags := &Flags{}
+	verbose := flags.Flags().AddBool("verbose", "v", "Verbose output")
+	program := flags.Flags().AddPositional("program", true, "", "Program to run")
+
+	err := flags.Parse([]string{"scriptname", "--"})
+	await := "required positional argument [program] missing"
+
+	if err == nil {
+		t.Errorf("No error found, got [%p], want pointer", err)
+	} else if err.Error() != await {
+		t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
+	}
+
+	err = flags.Parse([]string{"scriptname", "-v", "--", "-cmd", "--its-own-flag", "--", "arg"})
+
+	if err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if !*verbose || *program != "-cmd" {
+		t.Errorf("Wrong values, got [%t %s], want [%t %s]", *verbose, *program, true, "-cmd")
+	}
+
+	if !reflect.DeepEqual(flags.Rest(), []string{"--its-own-flag", "--", "arg"}) {
+		t.Errorf("Wrong rest arguments, got [%v], want [%v]", flags.Rest(), []string{"--its-own-flag", "--", "arg"})
+	}
+
+	err = flags.Parse([]string{"scriptname", "cmd"})
+
+	if err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if len(flags.Rest()) != 0 {
+		t.Errorf("Rest arguments of previous parse were kept, got [%v], want [%v]", flags.Rest(), []string{})
+	}
+}
+
+func TestDashAndEmptyArguments(t *testing.T) {
+	flags := &Flags{}
+	test := flags.Flags().AddString("test", "t", false, "", "Test flag")
+	input := flags.Flags().AddPositional("input", false, "", "Input file")
+	output := flags.Flags().AddPositional("output", false, "", "Output file")
+
+	err := flags.Parse([]string{"scriptname", "-", ""})
+
+	if err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if *input != "-" || *output != "" {
+		t.Errorf("Wrong values, got [%s %s], want [%s %s]", *input, *output, "-", "")
+	}
+
+	err = flags.Parse([]string{"scriptname", "--test", ""})
+
+	if err != nil || *test != "" {
+		t.Errorf("Empty value rejected, got [%v], want nil", err)
+	}
+
+	err = flags.Parse([]string{"scriptname", "--test", "-"})
+
+	if err != nil || *test != "-" {
+		t.Errorf("Lone dash not taken as value, got [%v %s], want [nil -]", err, *test)
+	}
+
+	err = flags.Parse([]string{"scriptname", "--test"})
+	await := "flag --test requires a value"
+
+	if err == nil || err.Error() != await {
+		t.Errorf("Wrong error message, got [%v], want [%s]", err, await)
+	}
+}
+
+func TestPositionalLists(t *testing.T) {
+	flags := &Flags{}
+	sources := flags.Flags().AddPositionalList("source", "+", nil, "Files to copy")
+	dest := flags.Flags().AddPositional("dest", true, "", "Destination")
+
+	err := flags.Parse([]string{"cp", "a", "b", "c", "dir"})
+
+	if err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if !reflect.DeepEqual(*sources, []string{"a", "b", "c"}) || *dest != "dir" {
+		t.Errorf("Wrong values, got [%v %s], want [%v %s]", *sources, *dest, []string{"a", "b", "c"}, "dir")
+	}
+
+	flags = &Flags{}
+	flags.Flags().AddPositionalList("source", "+", nil, "Files to copy")
+	flags.Flags().AddPositional("dest", true, "", "Destination")
+	err = flags.Parse([]string{"cp", "a"})
+	await := "required positional argument [dest] missing"
+
+	if err == nil {
+		t.Errorf("No error found, got [%p], want pointer", err)
+	} else if err.Error() != await {
+		t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
+	}
+
+	flags = &Flags{}
+	pair := flags.Flags().AddPositionalList("pair", "2", nil, "Two values")
+	optional := flags.Flags().AddPositionalList("optional", "?", []string{"default"}, "Optional value")
+	err = flags.Parse([]string{"tool", "a"})
+	await = "positional argument [pair] requires 2 values, got 1"
+
+	if err == nil {
+		t.Errorf("No error found, got [%p], want pointer", err)
+	} else if err.Error() != await {
+		t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
+	}
+
+	err = flags.Parse([]string{"tool", "a", "b"})
+
+	if err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if !reflect.DeepEqual(*pair, []string{"a", "b"}) || !reflect.DeepEqual(*optional, []string{"default"}) {
+		t.Errorf("Wrong values, got [%v %v], want [%v %v]", *pair, *optional, []string{"a", "b"}, []string{"default"})
+	}
+
+	err = flags.Parse([]string{"tool", "a", "b", "c", "d"})
+	await = "unknown positional argument d"
+
+	if err == nil {
+		t.Errorf("No error found, got [%p], want pointer", err)
+	} else if err.Error() != await {
+		t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
+	}
+
+	err = flags.Parse([]string{"tool", "a", "b", "c", "--", "d"})
+
+	if err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if !reflect.DeepEqual(flags.Rest(), []string{"d"}) {
+		t.Errorf("Wrong rest arguments, got [%v], want [%v]", flags.Rest(), []string{"d"})
 	}
 }
 
@@ -133,7 +568,7 @@ func TestUsage(t *testing.T) {
 	await := `title
 description
 
-Usage: title [-b] -s [positionalname]
+Usage: title [-b] -s STRINGNAME [positionalname]
 
 Flags:
 -b, --boolname           booldescription
@@ -153,3 +588,64 @@ positionalname           positionaldescription (Default: positionaldefault)
 		t.Errorf("Wrong Usage output, got\n%s\n\nwant\n\n%s", result, await)
 	}
 }
+
+func TestUsageOrder(t *testing.T) {
+	flags := &Flags{}
+	flags.Flags().AddString("zeta", "z", false, "", "Zeta option")
+	flags.Flags().AddBool("beta", "b", "Beta flag")
+	flags.Flags().AddString("alpha", "a", true, "", "Alpha option")
+	flags.Flags().AddBool("alpha-flag", "", "Alpha flag")
+
+	await := `title
+description
+
+Usage: title [-b] [--alpha-flag] [-z ZETA] -a ALPHA
+
+Flags:
+-b, --beta               Beta flag
+--alpha-flag             Alpha flag
+
+Options:
+-z, --zeta               Zeta option
+-a, --alpha              Alpha option
+`
+
+	for i := 0; i < 10; i++ {
+		if result := flags.UsageString("title", "description", nil); result != await {
+			t.Errorf("Wrong Usage output in declaration order, got\n%s\n\nwant\n\n%s", result, await)
+		}
+	}
+
+	flags.SetSorted(true)
+	await = `Error: something failed
+
+Usage: title [--alpha-flag] [-b] -a ALPHA [-z ZETA]
+
+Flags:
+--alpha-flag             Alpha flag
+-b, --beta               Beta flag
+
+Options:
+-a, --alpha              Alpha option
+-z, --zeta               Zeta option
+`
+
+	var output, erroutput bytes.Buffer
+	flags.SetOutput(&output)
+	flags.SetErrorOutput(&erroutput)
+	flags.Usage("title", "description", errors.New("something failed"))
+
+	if erroutput.String() != await {
+		t.Errorf("Wrong sorted Usage output, got\n%s\n\nwant\n\n%s", erroutput.String(), await)
+	}
+
+	if output.Len() != 0 {
+		t.Errorf("Usage with error written to output, got [%s], want []", output.String())
+	}
+
+	flags.Usage("title", "description", nil)
+
+	if output.Len() == 0 || output.String() != flags.UsageString("title", "description", nil) {
+		t.Errorf("Wrong Usage output, got\n%s\n\nwant\n\n%s", output.String(), flags.UsageString("title", "description", nil))
+	}
+}
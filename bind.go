@@ -0,0 +1,307 @@
+package argumentative
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// struct for the options of a struct field read from its "arg" tag
+type bindOptions struct {
+	name         string
+	short        string
+	help         string
+	env          string
+	nargs        string
+	choices      []string
+	defaultvalue string
+	hasDefault   bool
+	required     bool
+	count        bool
+	positional   bool
+	ignorecase   bool
+	negatable    bool
+	allowempty   bool
+	// names of the options given in the tag
+	given []string
+}
+
+// Register a flag for every field with an "arg" tag of the struct v points to, Parse fills the fields.
+// A tag like `arg:"name,short=n,required,default=x,env=NAME,help=Text"` sets the options of the flag,
+// "count" makes an int a count flag and "positional" a string or []string a positional argument,
+// "choices=a|b" limits a string to the given values, with "ignorecase" in any case,
+// "allowempty" lets a required string be given as "" and "negatable" adds --no-name to a bool.
+// Nested structs prefix the names of their fields with their own name and a dot.
+func (f *Flags) Bind(v interface{}) {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		panic("argumentative: Bind needs a pointer to a struct")
+	}
+	f.bindStruct(value.Elem(), "")
+}
+
+// Register the tagged fields of a struct, recursing into nested structs
+func (f *Flags) bindStruct(value reflect.Value, prefix string) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		tag, tagged := field.Tag.Lookup("arg")
+		// The exported fields of embedded structs are used even if their type is unexported
+		embedded := field.Anonymous && field.Type.Kind() == reflect.Struct
+		if tag == "-" || (!field.IsExported() && !embedded) {
+			continue
+		}
+		options := parseTag(field.Name, tag)
+		if options.name == "" {
+			options.name = kebabCase(field.Name)
+		}
+
+		if field.Type.Kind() == reflect.Struct {
+			// Embedded structs without tag add their fields without prefix
+			if embedded && !tagged {
+				f.bindStruct(value.Field(i), prefix)
+			} else {
+				f.bindStruct(value.Field(i), prefix+options.name+".")
+			}
+			continue
+		}
+		if tagged {
+			options.name = prefix + options.name
+			f.bindField(field.Name, value.Field(i), options)
+		}
+	}
+}
+
+// Read the options of an "arg" tag, the help text is last and may contain commas
+func parseTag(fieldname string, tag string) bindOptions {
+	name, tag, _ := strings.Cut(tag, ",")
+	options := bindOptions{name: name}
+	for tag != "" {
+		if help, ok := strings.CutPrefix(tag, "help="); ok {
+			options.help = help
+			break
+		}
+		var option string
+		option, tag, _ = strings.Cut(tag, ",")
+		key, value, _ := strings.Cut(option, "=")
+		options.given = append(options.given, key)
+		switch key {
+		case "short":
+			options.short = value
+		case "required":
+			options.required = true
+		case "default":
+			options.defaultvalue = value
+			options.hasDefault = true
+		case "env":
+			options.env = value
+		case "nargs":
+			options.nargs = value
+		case "count":
+			options.count = true
+		case "positional":
+			options.positional = true
+		case "choices":
+			options.choices = strings.Split(value, "|")
+		case "ignorecase":
+			options.ignorecase = true
+		case "negatable":
+			options.negatable = true
+		case "allowempty":
+			options.allowempty = true
+		default:
+			panic("argumentative: unknown option " + option + " in tag of field " + fieldname)
+		}
+	}
+	return options
+}
+
+// Panic for an option of the tag that does not apply to the kind of flag the field becomes
+func (o bindOptions) allow(fieldname string, kind string, allowed ...string) {
+	for _, option := range o.given {
+		found := false
+		for _, name := range allowed {
+			found = found || option == name
+		}
+		if !found {
+			panic("argumentative: option " + option + " in tag of field " + fieldname + " does not apply to " + kind)
+		}
+	}
+}
+
+// Register a flag or positional argument that stores its value in field
+func (f *Flags) bindField(fieldname string, field reflect.Value, options bindOptions) {
+	if options.positional && options.short != "" {
+		panic("argumentative: positional argument " + options.name + " can not have a short name")
+	}
+	if options.ignorecase && len(options.choices) == 0 {
+		panic("argumentative: option ignorecase in tag of field " + fieldname + " needs choices")
+	}
+	// Flags without options of their own are named by their type like "int flags"
+	typed := field.Type().String() + " flags"
+	switch ptr := field.Addr().Interface().(type) {
+	case *string:
+		if options.positional {
+			options.allow(fieldname, "positional arguments", "positional", "required", "default", "choices", "ignorecase")
+			positional := NewPositional(options.name, options.required, *ptr, options.help)
+			if options.hasDefault {
+				positional.Default = options.defaultvalue
+			}
+			if len(options.choices) > 0 {
+				checkChoices("["+options.name+"]", positional.Default, options.choices)
+				positional.Choices = options.choices
+				positional.IgnoreCase = options.ignorecase
+			}
+			positional.Value = ptr
+			*ptr = positional.Default
+			f.addPositional(&positional)
+			return
+		}
+		if len(options.choices) > 0 {
+			options.allow(fieldname, "choice flags", "short", "required", "default", "env", "choices", "ignorecase")
+			flag := NewChoiceFlag(options.name, options.short, options.required, "", options.choices, options.help)
+			flag.IgnoreCase = options.ignorecase
+			flag.Value = ptr
+			if !options.hasDefault && *ptr != "" {
+				options.defaultvalue, options.hasDefault = *ptr, true
+			}
+			bindDefault(&flag, options)
+			flag.Default = *ptr
+			f.bindValueFlag(&flag, options)
+			return
+		}
+		options.allow(fieldname, "string flags", "short", "required", "default", "env", "allowempty")
+		flag := NewStringFlag(options.name, options.short, options.required, "", options.help)
+		flag.AllowEmpty = options.allowempty
+		flag.Value = ptr
+		bindDefault(&flag, options)
+		flag.Default = *ptr
+		f.bindValueFlag(&flag, options)
+	case *int:
+		if options.count {
+			options.allow(fieldname, "count flags", "short", "env", "count")
+			flag := NewCountFlag(options.name, options.short, 0, options.help)
+			flag.Env = options.env
+			flag.Value = ptr
+			f.addCountFlag(flag)
+			return
+		}
+		options.allow(fieldname, typed, "short", "required", "default", "env")
+		flag := NewIntFlag(options.name, options.short, options.required, 0, options.help)
+		flag.Value = ptr
+		bindDefault(&flag, options)
+		flag.Default = *ptr
+		f.bindValueFlag(&flag, options)
+	case *int64:
+		options.allow(fieldname, typed, "short", "required", "default", "env")
+		flag := NewInt64Flag(options.name, options.short, options.required, 0, options.help)
+		flag.Value = ptr
+		bindDefault(&flag, options)
+		flag.Default = *ptr
+		f.bindValueFlag(&flag, options)
+	case *uint:
+		options.allow(fieldname, typed, "short", "required", "default", "env")
+		flag := NewUintFlag(options.name, options.short, options.required, 0, options.help)
+		flag.Value = ptr
+		bindDefault(&flag, options)
+		flag.Default = *ptr
+		f.bindValueFlag(&flag, options)
+	case *float64:
+		options.allow(fieldname, typed, "short", "required", "default", "env")
+		flag := NewFloat64Flag(options.name, options.short, options.required, 0, options.help)
+		flag.Value = ptr
+		bindDefault(&flag, options)
+		flag.Default = *ptr
+		f.bindValueFlag(&flag, options)
+	case *bool:
+		options.allow(fieldname, "bool flags", "short", "default", "env", "negatable")
+		flag := NewBoolFlag(options.name, options.short, options.help)
+		flag.Env = options.env
+		flag.Value = ptr
+		if options.hasDefault {
+			converted, err := parseBool(options.defaultvalue)
+			if err != nil {
+				panic("argumentative: invalid default " + options.defaultvalue + " for --" + options.name + ": expected boolean")
+			}
+			*ptr = converted
+		}
+		flag.Negatable = options.negatable
+		flag.Default = *ptr
+		f.addBoolFlag(flag)
+	case *[]string:
+		if options.positional {
+			options.allow(fieldname, "positional lists", "positional", "required", "default", "nargs")
+			nargs := options.nargs
+			if nargs == "" && options.required {
+				nargs = "+"
+			} else if nargs == "" {
+				nargs = "*"
+			}
+			defaultvalue := *ptr
+			if options.hasDefault {
+				defaultvalue = strings.Split(options.defaultvalue, ";")
+			}
+			positional := NewPositionalList(options.name, nargs, defaultvalue, options.help)
+			positional.Value = ptr
+			*ptr = append([]string(nil), defaultvalue...)
+			f.addPositional(&positional)
+			return
+		}
+		options.allow(fieldname, typed, "short", "required", "default", "env")
+		flag := NewStringSliceFlag(options.name, options.short, options.required, nil, options.help)
+		flag.Value = ptr
+		bindDefault(&flag, options)
+		flag.Default = append([]string(nil), *ptr...)
+		f.bindValueFlag(&flag, options)
+	case *[]int:
+		options.allow(fieldname, typed, "short", "required", "default", "env")
+		flag := NewIntSliceFlag(options.name, options.short, options.required, nil, options.help)
+		flag.Value = ptr
+		bindDefault(&flag, options)
+		flag.Default = append([]int(nil), *ptr...)
+		f.bindValueFlag(&flag, options)
+	default:
+		panic("argumentative: unsupported type " + field.Type().String() + " of field " + fieldname)
+	}
+}
+
+// Register a value flag with the environment variable from its tag
+func (f *Flags) bindValueFlag(flag valueFlag, options bindOptions) {
+	if options.env != "" {
+		flag.setEnv(options.env)
+	}
+	f.addValueFlag(options.name, options.short, flag)
+}
+
+// Assign the default value of a tag, the values of repeatable flags are separated by ";"
+func bindDefault(flag valueFlag, options bindOptions) {
+	if !options.hasDefault {
+		return
+	}
+	values := []string{options.defaultvalue}
+	if slice, ok := flag.(sliceFlag); ok {
+		slice.clear()
+		values = strings.Split(options.defaultvalue, ";")
+	}
+	for _, value := range values {
+		if err := flag.set(value); err != nil {
+			panic("argumentative: invalid default " + options.defaultvalue + " for --" + options.name + ": " + err.Error())
+		}
+	}
+}
+
+// Convert a field name like "OutputFile" or "HTTPPort" to a flag name like "output-file" or "http-port"
+func kebabCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			previous := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextLower) {
+				b.WriteByte('-')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,207 @@
+package argumentative
+
+import (
+	"reflect"
+	"testing"
+)
+
+type bindDatabase struct {
+	Host string `arg:"host,default=localhost,help=Database host"`
+	Port int    `arg:"port,default=5432,help=Database port"`
+}
+
+type bindCommon struct {
+	Verbose int `arg:"verbose,short=v,count,help=More output"`
+}
+
+type bindConfig struct {
+	bindCommon
+	Name     string       `arg:"name,short=n,required,help=Name of the service, used in logs"`
+	Ratio    float64      `arg:"ratio,default=0.5"`
+	Size     int64        `arg:"size"`
+	Workers  uint         `arg:",short=w,default=4"`
+	DryRun   bool         `arg:"dry-run"`
+	Tags     []string     `arg:"tag,short=t,default=a;b"`
+	Ports    []int        `arg:"ports"`
+	Database bindDatabase `arg:"db"`
+	Input    string       `arg:"input,positional,required,help=Input file"`
+	Files    []string     `arg:"files,positional"`
+	Ignored  string
+}
+
+func TestBind(t *testing.T) {
+	var config bindConfig
+	flags := &Flags{}
+	flags.Flags().Bind(&config)
+
+	if config.Database.Host != "localhost" || config.Database.Port != 5432 || config.Workers != 4 {
+		t.Errorf("Defaults not assigned, got [%s %d %d], want [%s %d %d]", config.Database.Host, config.Database.Port, config.Workers, "localhost", 5432, 4)
+	}
+
+	args := []string{"tool", "-vv", "--name", "api", "--ratio", "0.25", "--size", "12345678901", "-w", "8",
+		"--dry-run", "-t", "x", "--tag", "y", "--ports", "80", "--ports", "443", "--db.host", "db", "in", "f1", "f2"}
+	if err := flags.Parse(args); err != nil {
+		t.Fatalf("Error found, got [%s], want nil", err)
+	}
+
+	await := bindConfig{
+		bindCommon: bindCommon{Verbose: 2},
+		Name:       "api",
+		Ratio:      0.25,
+		Size:       12345678901,
+		Workers:    8,
+		DryRun:     true,
+		Tags:       []string{"x", "y"},
+		Ports:      []int{80, 443},
+		Database:   bindDatabase{Host: "db", Port: 5432},
+		Input:      "in",
+		Files:      []string{"f1", "f2"},
+	}
+	if !reflect.DeepEqual(config, await) {
+		t.Errorf("Struct not filled, got [%+v], want [%+v]", config, await)
+	}
+}
+
+func TestBindRequired(t *testing.T) {
+	var config bindConfig
+	flags := &Flags{}
+	flags.Flags().Bind(&config)
+
+	err := flags.Parse([]string{"tool", "in"})
+	await := "required flag --name missing"
+	if err == nil || err.Error() != await {
+		t.Errorf("Required field not checked, got [%v], want [%s]", err, await)
+	}
+}
+
+func TestBindUsage(t *testing.T) {
+	var config struct {
+		OutputFile string `arg:",short=o,help=Write to this file, not stdout"`
+		HTTPPort   int    `arg:",default=8080,help=Port to listen on"`
+	}
+	flags := &Flags{}
+	flags.Flags().Bind(&config)
+
+	await := `tool
+description
+
+Usage: tool [-o OUTPUT-FILE] [--http-port HTTP-PORT]
+
+Options:
+-o, --output-file        Write to this file, not stdout
+--http-port              Port to listen on (Default: 8080)
+`
+	if result := flags.UsageString("tool", "description", nil); result != await {
+		t.Errorf("Wrong Usage output, got\n%s\n\nwant\n\n%s", result, await)
+	}
+}
+
+func TestBindPanics(t *testing.T) {
+	tests := map[string]interface{}{
+		"no pointer": struct{}{},
+		"unknown option": &struct {
+			A string `arg:"a,unknown"`
+		}{},
+		"unsupported": &struct {
+			A int8 `arg:"a"`
+		}{},
+		"invalid default": &struct {
+			A int `arg:"a,default=x"`
+		}{},
+		"count on bool": &struct {
+			V bool `arg:"v,count"`
+		}{},
+		"negatable on int": &struct {
+			N int `arg:"n,negatable"`
+		}{},
+		"nargs on int": &struct {
+			N int `arg:"n,nargs=2"`
+		}{},
+		"choices on int": &struct {
+			N int `arg:"n,choices=1|2"`
+		}{},
+		"allowempty on int": &struct {
+			N int `arg:"n,allowempty"`
+		}{},
+		"env on positional": &struct {
+			P string `arg:"p,positional,env=P"`
+		}{},
+		"required on bool": &struct {
+			V bool `arg:"v,required"`
+		}{},
+		"default on count": &struct {
+			V int `arg:"v,count,default=2"`
+		}{},
+		"positional on int": &struct {
+			N int `arg:"n,positional"`
+		}{},
+		"nargs on string flag": &struct {
+			S []string `arg:"s,nargs=2"`
+		}{},
+		"ignorecase without choices": &struct {
+			S string `arg:"s,ignorecase"`
+		}{},
+	}
+	for name, value := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("No panic for %s", name)
+				}
+			}()
+			flags := &Flags{}
+			flags.Flags().Bind(value)
+		}()
+	}
+}
+
+func TestBindOptionMessage(t *testing.T) {
+	var config struct {
+		V bool `arg:"v,count"`
+	}
+	defer func() {
+		await := "argumentative: option count in tag of field V does not apply to bool flags"
+		if result := recover(); result != await {
+			t.Errorf("Wrong panic message, got [%v], want [%s]", result, await)
+		}
+	}()
+	flags := &Flags{}
+	flags.Flags().Bind(&config)
+}
+
+func TestKebabCase(t *testing.T) {
+	tests := map[string]string{
+		"Name":       "name",
+		"OutputFile": "output-file",
+		"HTTPPort":   "http-port",
+		"URL":        "url",
+		"Retry2Max":  "retry2-max",
+	}
+	for name, await := range tests {
+		if result := kebabCase(name); result != await {
+			t.Errorf("Wrong flag name for %s, got [%s], want [%s]", name, result, await)
+		}
+	}
+}
+
+func TestBindChoices(t *testing.T) {
+	var config struct {
+		Format string `arg:"format,choices=json|yaml,default=json,ignorecase"`
+		Mode   string `arg:"mode,positional,choices=start|stop"`
+	}
+	flags := &Flags{}
+	flags.Flags().Bind(&config)
+
+	if err := flags.Parse([]string{"tool", "--format", "YAML", "stop"}); err != nil {
+		t.Fatalf("Error found, got [%s], want nil", err)
+	}
+	if config.Format != "yaml" || config.Mode != "stop" {
+		t.Errorf("Wrong values, got [%s %s], want [%s %s]", config.Format, config.Mode, "yaml", "stop")
+	}
+
+	err := flags.Parse([]string{"tool", "--format", "xml"})
+	await := `invalid value "xml" for --format: expected one of json, yaml`
+	if err == nil || err.Error() != await {
+		t.Errorf("Wrong error message, got [%v], want [%s]", err, await)
+	}
+}
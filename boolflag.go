@@ -1,7 +1,9 @@
 package argumentative
 
 import (
-	"fmt"
+	"errors"
+	"strconv"
+	"strings"
 )
 
 // struct for a single configured flag
@@ -9,7 +11,10 @@ type BoolFlag struct {
 	Longflag    string
 	Shortflag   string
 	Description string
+	Env         string
 	Required    bool
+	Negatable   bool
+	Default     bool
 	Value       *bool
 }
 
@@ -26,28 +31,56 @@ func NewBoolFlag(longflag string, shortflag string, description string) BoolFlag
 	return flag
 }
 
-// Generate the string for the long description
-func (f *BoolFlag) GetLongDescription() string {
-	flagnames := ""
-	if f.Shortflag != "" {
-		flagnames += "-" + f.Shortflag + ", "
+// Restore the value to its default
+func (f *BoolFlag) reset() {
+	*f.Value = f.Default
+}
+
+// Copy the definition with its own value storage
+func (f BoolFlag) clone() BoolFlag {
+	f.Value = new(bool)
+	f.reset()
+	return f
+}
+
+// Collect the metadata used for help, documentation and completion
+func (f *BoolFlag) info() flagInfo {
+	defaultvalue := ""
+	if f.Default {
+		defaultvalue = "true"
 	}
-	flagnames += "--" + f.Longflag
-	output := fmt.Sprintf("%-25s", flagnames)
-	if f.Description != "" {
-		output += f.Description
+	return flagInfo{
+		longflag:     f.Longflag,
+		shortflag:    f.Shortflag,
+		description:  f.Description,
+		typename:     "bool",
+		defaultvalue: defaultvalue,
+		env:          f.Env,
+		negatable:    f.Negatable,
 	}
-	return output
+}
+
+// Generate the string for the long description
+func (f *BoolFlag) GetLongDescription() string {
+	return longDescription(f.info())
 }
 
 // Generate the string for a short description in the 'Usage:' line
 func (f *BoolFlag) GetShortDescription() string {
-	output := " ["
-	if f.Shortflag != "" {
-		output += "-" + f.Shortflag
-	} else {
-		output += "--" + f.Longflag
+	return shortDescription(f.info())
+}
+
+// Convert a boolean value, besides true and false also yes, no, on, off, 1 and 0 in any case
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "yes", "on":
+		return true, nil
+	case "no", "off":
+		return false, nil
+	}
+	converted, err := strconv.ParseBool(strings.ToLower(value))
+	if err != nil {
+		return false, errors.New("expected boolean")
 	}
-	output += "]"
-	return output
+	return converted, nil
 }
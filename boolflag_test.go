@@ -57,3 +57,81 @@ func TestGetShortBoolDescription(t *testing.T) {
 		t.Errorf("Generation of short description no required failed, got [%s], want [%s]", result, await)
 	}
 }
+
+func TestParseBool(t *testing.T) {
+	for _, value := range []string{"true", "TRUE", "1", "yes", "Yes", "on", "t"} {
+		if result, err := parseBool(value); err != nil || !result {
+			t.Errorf("Wrong conversion of %s, got [%t %v], want [%t]", value, result, err, true)
+		}
+	}
+	for _, value := range []string{"false", "0", "no", "OFF", "f"} {
+		if result, err := parseBool(value); err != nil || result {
+			t.Errorf("Wrong conversion of %s, got [%t %v], want [%t]", value, result, err, false)
+		}
+	}
+	if _, err := parseBool("maybe"); err == nil || err.Error() != "expected boolean" {
+		t.Errorf("Invalid value accepted, got [%v], want [%s]", err, "expected boolean")
+	}
+}
+
+func TestNegatableBool(t *testing.T) {
+	flags := &Flags{}
+	color := flags.Flags().AddNegatableBool("color", "c", true, "Colored output")
+	verbose := flags.Flags().AddBool("verbose", "v", "Verbose output")
+
+	if err := flags.Parse([]string{"tool"}); err != nil || !*color {
+		t.Errorf("Default not assigned, got [%t %v], want [%t]", *color, err, true)
+	}
+
+	if err := flags.Parse([]string{"tool", "--no-color"}); err != nil || *color {
+		t.Errorf("Negation not assigned, got [%t %v], want [%t]", *color, err, false)
+	}
+
+	if err := flags.Parse([]string{"tool", "--color=on", "--verbose=yes"}); err != nil || !*color || !*verbose {
+		t.Errorf("Explicit values not assigned, got [%t %t %v], want [%t %t]", *color, *verbose, err, true, true)
+	}
+
+	if err := flags.Parse([]string{"tool", "--color=off"}); err != nil || *color {
+		t.Errorf("Explicit false not assigned, got [%t %v], want [%t]", *color, err, false)
+	}
+
+	err := flags.Parse([]string{"tool", "--no-verbose"})
+	await := "unknown flag --no-verbose"
+	if err == nil || err.Error() != await {
+		t.Errorf("Flag not declared negatable was negated, got [%v], want [%s]", err, await)
+	}
+
+	err = flags.Parse([]string{"tool", "--no-color=true"})
+	await = `invalid value "true" for --no-color: flag does not take a value`
+	if err == nil || err.Error() != await {
+		t.Errorf("Negation took a value, got [%v], want [%s]", err, await)
+	}
+
+	t.Setenv("TEST_NEGATABLE_COLOR", "no")
+	flags.SetEnv("color", "TEST_NEGATABLE_COLOR")
+	if err := flags.Parse([]string{"tool"}); err != nil || *color {
+		t.Errorf("Value from environment not assigned, got [%t %v], want [%t]", *color, err, false)
+	}
+	*color = false
+	if err := flags.Parse([]string{"tool", "-c"}); err != nil || !*color {
+		t.Errorf("Command line does not override environment, got [%t %v], want [%t]", *color, err, true)
+	}
+}
+
+func TestNegatableBoolDescription(t *testing.T) {
+	flag := NewBoolFlag("color", "", "Colored output")
+	flag.Negatable = true
+	flag.Default = true
+
+	result := flag.GetLongDescription()
+	await := "--[no-]color             Colored output (Default: true)"
+	if result != await {
+		t.Errorf("Generation of long description failed, got [%s], want [%s]", result, await)
+	}
+
+	result = flag.GetShortDescription()
+	await = " [--[no-]color]"
+	if result != await {
+		t.Errorf("Generation of short description failed, got [%s], want [%s]", result, await)
+	}
+}
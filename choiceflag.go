@@ -0,0 +1,126 @@
+package argumentative
+
+import (
+	"fmt"
+	"strings"
+)
+
+// struct for a single configured flag that takes one of a fixed set of values
+type ChoiceFlag struct {
+	Longflag    string
+	Shortflag   string
+	Description string
+	Env         string
+	Required    bool
+	IgnoreCase  bool
+	Default     string
+	Choices     []string
+	Value       *string
+}
+
+// Factory to generate a new flag
+func NewChoiceFlag(longflag string, shortflag string, required bool, defaultvalue string, choices []string, description string) ChoiceFlag {
+	flag := ChoiceFlag{
+		Longflag:    longflag,
+		Shortflag:   shortflag,
+		Description: description,
+		Required:    required,
+		Default:     defaultvalue,
+		Choices:     choices,
+		Value:       new(string),
+	}
+	if defaultvalue != "" {
+		*flag.Value = defaultvalue
+	}
+
+	return flag
+}
+
+// Restore the value to its default
+func (f *ChoiceFlag) reset() {
+	*f.Value = f.Default
+}
+
+// Put back a value taken from value() when a new one is rejected
+func (f *ChoiceFlag) restore(value interface{}) {
+	*f.Value = value.(string)
+}
+
+// Copy the definition with its own value storage
+func (f *ChoiceFlag) clone() valueFlag {
+	flag := *f
+	flag.Value = new(string)
+	flag.reset()
+	return &flag
+}
+
+// Assign a value from the command line, it has to be one of the choices
+func (f *ChoiceFlag) set(value string) error {
+	choice, err := matchChoice(value, f.Choices, f.IgnoreCase)
+	if err != nil {
+		return err
+	}
+	*f.Value = choice
+	return nil
+}
+
+// Check if a required flag has no value
+func (f *ChoiceFlag) isMissing(given bool) bool {
+	return f.Required && *f.Value == ""
+}
+
+// Get the converted value for validators
+func (f *ChoiceFlag) value() interface{} {
+	return *f.Value
+}
+
+// Get the name of the environment variable used as fallback
+func (f *ChoiceFlag) env() string {
+	return f.Env
+}
+
+// Set the name of the environment variable used as fallback
+func (f *ChoiceFlag) setEnv(name string) {
+	f.Env = name
+}
+
+// Collect the metadata used for help, documentation and completion
+func (f *ChoiceFlag) info() flagInfo {
+	return flagInfo{
+		longflag:     f.Longflag,
+		shortflag:    f.Shortflag,
+		description:  f.Description,
+		typename:     "choice",
+		defaultvalue: f.Default,
+		env:          f.Env,
+		required:     f.Required,
+		repeatable:   false,
+		takesValue:   true,
+		choices:      f.Choices,
+	}
+}
+
+// Generate the string for the long description
+func (f *ChoiceFlag) GetLongDescription() string {
+	return longDescription(f.info())
+}
+
+// Generate the string for a short description in the 'Usage:' line
+func (f *ChoiceFlag) GetShortDescription() string {
+	return shortDescription(f.info())
+}
+
+// Find the choice a value stands for, with ignorecase the value may be in any case
+func matchChoice(value string, choices []string, ignorecase bool) (string, error) {
+	for _, choice := range choices {
+		if value == choice || (ignorecase && strings.EqualFold(value, choice)) {
+			return choice, nil
+		}
+	}
+	return "", fmt.Errorf("expected one of %s", strings.Join(choices, ", "))
+}
+
+// Generate the list of choices shown in help like "{json,yaml,table}"
+func choiceList(choices []string) string {
+	return "{" + strings.Join(choices, ",") + "}"
+}
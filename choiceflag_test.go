@@ -0,0 +1,134 @@
+package argumentative
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewChoiceFlag(t *testing.T) {
+	flag := NewChoiceFlag("format", "f", false, "table", []string{"json", "yaml", "table"}, "description")
+
+	if flag.Longflag != "format" {
+		t.Errorf("Longflag assignment wrong, got [%s], want [%s]", flag.Longflag, "format")
+	}
+
+	if len(flag.Choices) != 3 {
+		t.Errorf("Choices assignment wrong, got [%v], want [%v]", flag.Choices, []string{"json", "yaml", "table"})
+	}
+
+	if *flag.Value != "table" {
+		t.Errorf("Assignment of default to value wrong, got [%s], want [%s]", *flag.Value, flag.Default)
+	}
+}
+
+func TestSetChoice(t *testing.T) {
+	flag := NewChoiceFlag("format", "f", false, "", []string{"json", "yaml"}, "description")
+
+	if err := flag.set("yaml"); err != nil || *flag.Value != "yaml" {
+		t.Errorf("Valid choice not assigned, got [%s %v], want [%s]", *flag.Value, err, "yaml")
+	}
+
+	err := flag.set("YAML")
+	await := "expected one of json, yaml"
+	if err == nil || err.Error() != await {
+		t.Errorf("Wrong case accepted, got [%v], want [%s]", err, await)
+	}
+
+	flag.IgnoreCase = true
+	if err := flag.set("JSON"); err != nil || *flag.Value != "json" {
+		t.Errorf("Choice in other case not assigned as declared, got [%s %v], want [%s]", *flag.Value, err, "json")
+	}
+}
+
+func TestGetChoiceDescriptions(t *testing.T) {
+	flag := NewChoiceFlag("format", "f", false, "table", []string{"json", "yaml", "table"}, "Output format")
+
+	result := flag.GetLongDescription()
+	await := "-f, --format {json,yaml,table}\n                         Output format (Default: table)"
+	if result != await {
+		t.Errorf("Generation of long description failed, got [%s], want [%s]", result, await)
+	}
+
+	result = flag.GetShortDescription()
+	await = " [-f {json,yaml,table}]"
+	if result != await {
+		t.Errorf("Generation of short description failed, got [%s], want [%s]", result, await)
+	}
+}
+
+func TestChoices(t *testing.T) {
+	flags := &Flags{}
+	format := flags.Flags().AddChoice("format", "f", false, "table", []string{"json", "yaml", "table"}, "Output format")
+	mode := flags.Flags().AddPositionalChoice("mode", true, "", []string{"start", "stop"}, "Mode")
+
+	if err := flags.Parse([]string{"tool", "-f", "json", "start"}); err != nil {
+		t.Errorf("Error found, got [%s], want nil", err)
+	}
+	if *format != "json" || *mode != "start" {
+		t.Errorf("Wrong values, got [%s %s], want [%s %s]", *format, *mode, "json", "start")
+	}
+
+	err := flags.Parse([]string{"tool", "--format=xml", "start"})
+	await := `invalid value "xml" for --format: expected one of json, yaml, table`
+	if err == nil || err.Error() != await {
+		t.Errorf("Wrong error message, got [%v], want [%s]", err, await)
+	}
+
+	err = flags.Parse([]string{"tool", "restart"})
+	await = `invalid value "restart" for [mode]: expected one of start, stop`
+	var invalid *InvalidValueError
+	if err == nil || err.Error() != await {
+		t.Errorf("Wrong error message, got [%v], want [%s]", err, await)
+	} else if !errors.As(err, &invalid) || !invalid.Positional {
+		t.Errorf("No InvalidValueError for positional argument, got [%v]", err)
+	}
+
+	flags.SetIgnoreCase("format")
+	flags.SetIgnoreCase("mode")
+	if err := flags.Parse([]string{"tool", "-f", "YAML", "Stop"}); err != nil {
+		t.Errorf("Error found, got [%s], want nil", err)
+	}
+	if *format != "yaml" || *mode != "stop" {
+		t.Errorf("Wrong values ignoring case, got [%s %s], want [%s %s]", *format, *mode, "yaml", "stop")
+	}
+}
+
+func TestChoiceUsage(t *testing.T) {
+	flags := &Flags{}
+	flags.Flags().AddChoice("format", "f", false, "table", []string{"json", "yaml", "table"}, "Output format")
+	flags.Flags().AddPositionalChoice("mode", true, "", []string{"start", "stop"}, "Mode")
+
+	await := `title
+description
+
+Usage: title [-f {json,yaml,table}] {start,stop}
+
+Options:
+-f, --format {json,yaml,table}  Output format (Default: table)
+
+Positional arguments:
+mode {start,stop}               Mode
+`
+	if result := flags.UsageString("title", "description", nil); result != await {
+		t.Errorf("Wrong Usage output, got\n%s\n\nwant\n\n%s", result, await)
+	}
+}
+
+func TestChoicePanics(t *testing.T) {
+	tests := map[string]func(flags *Flags){
+		"no choices":      func(flags *Flags) { flags.AddChoice("format", "f", false, "", nil, "") },
+		"invalid default": func(flags *Flags) { flags.AddChoice("format", "f", false, "xml", []string{"json"}, "") },
+		"not a choice":    func(flags *Flags) { flags.AddString("name", "n", false, "", ""); flags.SetIgnoreCase("name") },
+	}
+	for name, test := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("No panic for %s", name)
+				}
+			}()
+			flags := &Flags{}
+			test(flags.Flags())
+		}()
+	}
+}
@@ -0,0 +1,25 @@
+package argumentative
+
+// struct for a single configured subcommand with its own set of flags
+type Command struct {
+	Name        string
+	Description string
+	Flags       *Flags
+}
+
+// Factory to generate a new subcommand
+func NewCommand(name string, description string) Command {
+	command := Command{
+		Name:        name,
+		Description: description,
+		Flags:       &Flags{},
+	}
+	command.Flags.Flags()
+
+	return command
+}
+
+// Generate the string for the long description
+func (c *Command) GetLongDescription() string {
+	return formatEntry(c.Name, c.Description, defaultColumn, 0)
+}
@@ -0,0 +1,29 @@
+package argumentative
+
+import "testing"
+
+func TestNewCommand(t *testing.T) {
+	command := NewCommand("name", "description")
+
+	if command.Name != "name" {
+		t.Errorf("Name assignment wrong, got [%s], want [%s]", command.Name, "name")
+	}
+
+	if command.Description != "description" {
+		t.Errorf("Description assignment wrong, got [%s], want [%s]", command.Description, "description")
+	}
+
+	if command.Flags == nil || command.Flags.valueflags == nil {
+		t.Errorf("Flags of command not initialized, got [%v]", command.Flags)
+	}
+}
+
+func TestGetLongCommandDescription(t *testing.T) {
+	command := NewCommand("name", "description")
+	result := command.GetLongDescription()
+	await := "name                     description"
+
+	if result != await {
+		t.Errorf("Generation of long description failed, got [%s], want [%s]", result, await)
+	}
+}
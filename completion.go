@@ -0,0 +1,454 @@
+package argumentative
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// struct for a set of flags reached by a path of subcommands
+type completionContext struct {
+	path  []string
+	flags *Flags
+}
+
+// Write a completion script for the program name, shell is "bash", "zsh", "fish" or "powershell"
+func (f *Flags) WriteCompletion(w io.Writer, name string, shell string) error {
+	var script string
+	switch shell {
+	case "bash":
+		script = bashCompletion(f, name)
+	case "zsh":
+		script = zshCompletion(f, name)
+	case "fish":
+		script = fishCompletion(f, name)
+	case "powershell":
+		script = powershellCompletion(f, name)
+	default:
+		return fmt.Errorf("unknown shell %s for completion, use bash, zsh, fish or powershell", shell)
+	}
+	_, err := io.WriteString(w, script)
+	return err
+}
+
+// Collect the root flags and the flags of all subcommands, parents first
+func completionContexts(f *Flags, path []string) []completionContext {
+	contexts := []completionContext{{path: path, flags: f}}
+	for _, command := range f.commands {
+		subpath := append(append([]string(nil), path...), command.Name)
+		contexts = append(contexts, completionContexts(command.Flags, subpath)...)
+	}
+	return contexts
+}
+
+// Collect the flags offered for completion, negatable flags are followed by their "no-" form
+func completionInfos(f *Flags) []flagInfo {
+	var infos []flagInfo
+	for _, info := range f.flagInfos() {
+		infos = append(infos, info)
+		if info.negatable {
+			infos = append(infos, flagInfo{longflag: "no-" + info.longflag, description: info.description, typename: "bool"})
+		}
+	}
+	return infos
+}
+
+// Get the names of all subcommands of a set of flags
+func commandNames(f *Flags) []string {
+	names := make([]string, 0, len(f.commands))
+	for _, command := range f.commands {
+		names = append(names, command.Name)
+	}
+	return names
+}
+
+// Get the choices of all positional arguments of a set of flags
+func positionalChoices(f *Flags) []string {
+	var choices []string
+	for _, positional := range f.positionalInfos() {
+		choices = append(choices, positional.choices...)
+	}
+	return choices
+}
+
+// Turn a program or command name into a shell function name
+func identifier(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// Generate a bash completion script
+func bashCompletion(f *Flags, name string) string {
+	function := "_" + identifier(name)
+	contexts := completionContexts(f, nil)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# bash completion for %s, generated by argumentative\n\n", name)
+	fmt.Fprintf(&b, "%s() {\n", function)
+	b.WriteString("    local cur=\"${COMP_WORDS[COMP_CWORD]}\" prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
+	b.WriteString("    local context=\"\" word i\n")
+	b.WriteString("    for ((i = 1; i < COMP_CWORD; i++)); do\n")
+	b.WriteString("        word=\"${COMP_WORDS[i]}\"\n")
+	b.WriteString("        case \"$context:$word\" in\n")
+	for _, context := range contexts[1:] {
+		parent := strings.Join(context.path[:len(context.path)-1], " ")
+		fmt.Fprintf(&b, "            %s) context=%s ;;\n", bashQuote(parent+":"+context.path[len(context.path)-1]), bashQuote(strings.Join(context.path, " ")))
+	}
+	b.WriteString("        esac\n")
+	b.WriteString("    done\n\n")
+
+	b.WriteString("    local flags=\"\" repeatable=\"\" valueflags=\"\" commands=\"\" choices=() values=()\n")
+	b.WriteString("    case \"$context\" in\n")
+	for _, context := range contexts {
+		var flags, repeatable, valueflags, choices []string
+		for _, info := range completionInfos(context.flags) {
+			short := ""
+			if info.shortflag != "" {
+				short = "-" + info.shortflag
+			}
+			flags = append(flags, "--"+info.longflag+":"+short)
+			if info.repeatable {
+				repeatable = append(repeatable, "--"+info.longflag)
+			}
+			if info.takesValue {
+				valueflags = append(valueflags, strings.TrimSpace("--"+info.longflag+" "+short))
+			}
+			// Every choice is an entry of its own, so choices may contain blanks and commas
+			for _, choice := range info.choices {
+				choices = append(choices, bashQuote("--"+info.longflag+"="+choice))
+				if short != "" {
+					choices = append(choices, bashQuote(short+"="+choice))
+				}
+			}
+		}
+		var values []string
+		for _, value := range positionalChoices(context.flags) {
+			values = append(values, bashQuote(value))
+		}
+		fmt.Fprintf(&b, "        %s)\n", bashQuote(strings.Join(context.path, " ")))
+		fmt.Fprintf(&b, "            flags=%s\n", bashQuote(strings.Join(flags, " ")))
+		fmt.Fprintf(&b, "            repeatable=%s\n", bashQuote(strings.Join(repeatable, " ")))
+		fmt.Fprintf(&b, "            valueflags=%s\n", bashQuote(strings.Join(valueflags, " ")))
+		fmt.Fprintf(&b, "            commands=%s\n", bashQuote(strings.Join(commandNames(context.flags), " ")))
+		fmt.Fprintf(&b, "            choices=(%s)\n", strings.Join(choices, " "))
+		fmt.Fprintf(&b, "            values=(%s)\n", strings.Join(values, " "))
+		b.WriteString("            ;;\n")
+	}
+	b.WriteString("    esac\n\n")
+
+	// Choices are matched without compgen -W, which would expand them a second time
+	b.WriteString(`    local found=""
+    COMPREPLY=()
+    for word in "${choices[@]}"; do
+        if [[ "$prev" == "${word%%=*}" ]]; then
+            found=1
+            word="${word#*=}"
+            [[ "$word" == "$cur"* ]] && COMPREPLY+=("$word")
+        fi
+    done
+    [[ -n "$found" ]] && return
+
+    if [[ -n "$valueflags" && " $valueflags " == *" $prev "* ]]; then
+        COMPREPLY=($(compgen -f -- "$cur"))
+        return
+    fi
+
+    if [[ "$cur" == -* ]]; then
+        local flag long short candidates="" used=" ${COMP_WORDS[*]:1:COMP_CWORD-1} "
+        for flag in $flags; do
+            long="${flag%%:*}"
+            short="${flag#*:}"
+            # flags that can not be repeated are only offered once
+            if [[ " $repeatable " != *" $long "* ]]; then
+                if [[ "$used" == *" $long "* || "$used" == *" $long="* || ( -n "$short" && "$used" == *" $short "* ) ]]; then
+                    continue
+                fi
+            fi
+            candidates="$candidates $long $short"
+        done
+        COMPREPLY=($(compgen -W "$candidates" -- "$cur"))
+        return
+    fi
+
+    if [[ -n "$commands" ]]; then
+        COMPREPLY=($(compgen -W "$commands" -- "$cur"))
+        return
+    fi
+
+    if ((${#values[@]})); then
+        for word in "${values[@]}"; do
+            [[ "$word" == "$cur"* ]] && COMPREPLY+=("$word")
+        done
+        return
+    fi
+
+    COMPREPLY=($(compgen -f -- "$cur"))
+}
+
+`)
+	fmt.Fprintf(&b, "complete -o filenames -F %s %s\n", function, name)
+	return b.String()
+}
+
+// Quote text as a single bash word without any expansion
+func bashQuote(text string) string {
+	return "'" + strings.ReplaceAll(text, "'", `'\''`) + "'"
+}
+
+// Escape text for a description inside a single quoted zsh _arguments spec
+func zshEscape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `'\''`, "[", `\[`, "]", `\]`, ":", `\:`).Replace(text)
+}
+
+// Generate a zsh completion script
+func zshCompletion(f *Flags, name string) string {
+	function := "_" + identifier(name)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "#compdef %s\n\n# zsh completion for %s, generated by argumentative\n", name, name)
+	for _, context := range completionContexts(f, nil) {
+		prefix := function
+		for _, command := range context.path {
+			prefix += "_" + identifier(command)
+		}
+
+		fmt.Fprintf(&b, "\n%s() {\n", prefix)
+		if len(context.flags.commands) > 0 {
+			b.WriteString("    local context state state_descr line\n    typeset -A opt_args\n\n    _arguments -C")
+		} else {
+			b.WriteString("    _arguments")
+		}
+		for _, info := range completionInfos(context.flags) {
+			short, long := "-"+info.shortflag, "--"+info.longflag
+			if info.takesValue {
+				short, long = short+"+", long+"="
+			}
+			// Flags that can not be repeated exclude themselves once given
+			exclusion := "*"
+			if !info.repeatable && info.shortflag != "" {
+				exclusion = "(-" + info.shortflag + " --" + info.longflag + ")"
+			} else if !info.repeatable {
+				exclusion = "(--" + info.longflag + ")"
+			}
+			rest := "[" + zshEscape(info.description) + "]"
+			if len(info.choices) > 0 {
+				rest += ":" + zshEscape(info.longflag) + ":(" + zshEscape(strings.Join(info.choices, " ")) + ")"
+			} else if info.takesValue {
+				rest += ":" + zshEscape(info.longflag) + ":_files"
+			}
+			if info.shortflag != "" {
+				fmt.Fprintf(&b, " \\\n        '%s'{%s,%s}'%s'", exclusion, short, long, rest)
+			} else {
+				fmt.Fprintf(&b, " \\\n        '%s%s%s'", exclusion, long, rest)
+			}
+		}
+		if len(context.flags.commands) > 0 {
+			b.WriteString(" \\\n        '1: :->command' \\\n        '*:: :->args'\n\n")
+			b.WriteString("    case $state in\n        command)\n            local -a commands\n            commands=(\n")
+			for _, command := range context.flags.commands {
+				fmt.Fprintf(&b, "                '%s:%s'\n", zshEscape(command.Name), zshEscape(command.Description))
+			}
+			b.WriteString("            )\n            _describe -t commands command commands\n            ;;\n        args)\n            case $line[1] in\n")
+			for _, command := range context.flags.commands {
+				fmt.Fprintf(&b, "                %s) %s_%s ;;\n", command.Name, prefix, identifier(command.Name))
+			}
+			b.WriteString("            esac\n            ;;\n    esac\n")
+		} else if choices := positionalChoices(context.flags); len(choices) > 0 {
+			fmt.Fprintf(&b, " \\\n        '*:value:(%s)'\n", zshEscape(strings.Join(choices, " ")))
+		} else if len(context.flags.positionals) > 0 {
+			b.WriteString(" \\\n        '*:file:_files'\n")
+		} else {
+			b.WriteString("\n")
+		}
+		b.WriteString("}\n")
+	}
+
+	fmt.Fprintf(&b, "\nif [ \"$funcstack[1]\" = \"%s\" ]; then\n    %s \"$@\"\nelse\n    compdef %s %s\nfi\n", function, function, function, name)
+	return b.String()
+}
+
+// Quote text as single quoted fish string
+func fishQuote(text string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(text) + "'"
+}
+
+// Generate a fish completion script
+func fishCompletion(f *Flags, name string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# fish completion for %s, generated by argumentative\n", name)
+	for _, context := range completionContexts(f, nil) {
+		// The context is active when its path of commands was seen and no subcommand of it yet
+		var conditions []string
+		for _, command := range context.path {
+			conditions = append(conditions, "__fish_seen_subcommand_from "+command)
+		}
+		commands := commandNames(context.flags)
+		if len(commands) > 0 {
+			conditions = append(conditions, "not __fish_seen_subcommand_from "+strings.Join(commands, " "))
+		}
+
+		b.WriteString("\n")
+		for _, command := range context.flags.commands {
+			condition := "__fish_use_subcommand"
+			if len(context.path) > 0 {
+				condition = strings.Join(conditions, "; and ")
+			}
+			fmt.Fprintf(&b, "complete -c %s -n %s -f -a %s -d %s\n", name, fishQuote(condition), fishQuote(command.Name), fishQuote(command.Description))
+		}
+		if choices := positionalChoices(context.flags); len(choices) > 0 && len(commands) == 0 {
+			line := "complete -c " + name
+			if len(conditions) > 0 {
+				line += " -n " + fishQuote(strings.Join(conditions, "; and "))
+			}
+			b.WriteString(line + " -f -a " + fishQuote(strings.Join(choices, " ")) + "\n")
+		}
+		for _, info := range completionInfos(context.flags) {
+			names := "-l " + info.longflag
+			if info.shortflag != "" {
+				names = "-s " + info.shortflag + " " + names
+			}
+			flagconditions := conditions
+			if !info.repeatable {
+				flagconditions = append(append([]string(nil), conditions...), "not __fish_seen_argument "+names)
+			}
+			line := "complete -c " + name
+			if len(flagconditions) > 0 {
+				line += " -n " + fishQuote(strings.Join(flagconditions, "; and "))
+			}
+			line += " " + names
+			if len(info.choices) > 0 {
+				line += " -r -f -a " + fishQuote(strings.Join(info.choices, " "))
+			} else if info.takesValue {
+				line += " -r -F"
+			}
+			if info.description != "" {
+				line += " -d " + fishQuote(info.description)
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+	return b.String()
+}
+
+// Quote text as single quoted PowerShell string
+func powershellQuote(text string) string {
+	return "'" + strings.ReplaceAll(text, "'", "''") + "'"
+}
+
+// Generate the elements of a PowerShell array of quoted strings
+func powershellList(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, powershellQuote(value))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// Generate a PowerShell completion script
+func powershellCompletion(f *Flags, name string) string {
+	contexts := completionContexts(f, nil)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# powershell completion for %s, generated by argumentative\n\n", name)
+	fmt.Fprintf(&b, "Register-ArgumentCompleter -Native -CommandName %s -ScriptBlock {\n", powershellQuote(name))
+	b.WriteString(`    param($wordToComplete, $commandAst, $cursorPosition)
+
+    $words = @($commandAst.CommandElements | Where-Object { $_.Extent.EndOffset -lt $cursorPosition } | ForEach-Object { $_.ToString() })
+    $context = ''
+    for ($i = 1; $i -lt $words.Count; $i++) {
+        $candidate = ($context + ' ' + $words[$i]).Trim()
+        if ($candidate -in @(`)
+	var paths []string
+	for _, context := range contexts[1:] {
+		paths = append(paths, powershellQuote(strings.Join(context.path, " ")))
+	}
+	b.WriteString(strings.Join(paths, ", "))
+	b.WriteString(`)) { $context = $candidate }
+    }
+
+    $flags = @()
+    $commands = @()
+    $values = @()
+    switch ($context) {
+`)
+	for _, context := range contexts {
+		fmt.Fprintf(&b, "        %s {\n            $flags = @(\n", powershellQuote(strings.Join(context.path, " ")))
+		for _, info := range completionInfos(context.flags) {
+			short := ""
+			if info.shortflag != "" {
+				short = "-" + info.shortflag
+			}
+			description := info.description
+			if description == "" {
+				description = "--" + info.longflag
+			}
+			fmt.Fprintf(&b, "                @{ Long = %s; Short = %s; Description = %s; Repeatable = $%t; Value = $%t; Choices = @(%s) }\n",
+				powershellQuote("--"+info.longflag), powershellQuote(short), powershellQuote(description), info.repeatable, info.takesValue, powershellList(info.choices))
+		}
+		b.WriteString("            )\n            $commands = @(\n")
+		for _, command := range context.flags.commands {
+			description := command.Description
+			if description == "" {
+				description = command.Name
+			}
+			fmt.Fprintf(&b, "                @{ Name = %s; Description = %s }\n", powershellQuote(command.Name), powershellQuote(description))
+		}
+		fmt.Fprintf(&b, "            )\n            $values = @(%s)\n        }\n", powershellList(positionalChoices(context.flags)))
+	}
+	b.WriteString(`    }
+
+    $previous = if ($words.Count -gt 1) { $words[-1] } else { '' }
+    $valueflag = $flags | Where-Object { $_.Value -and ($_.Long -eq $previous -or ($_.Short -and $_.Short -eq $previous)) }
+    if ($valueflag -and $valueflag.Choices.Count -gt 0) {
+        foreach ($choice in $valueflag.Choices) {
+            if ($choice -like "$wordToComplete*") {
+                [System.Management.Automation.CompletionResult]::new($choice, $choice, 'ParameterValue', $choice)
+            }
+        }
+        return
+    }
+
+    if (-not $valueflag -and $wordToComplete -like '-*') {
+        foreach ($flag in $flags) {
+            # flags that can not be repeated are only offered once
+            if (-not $flag.Repeatable -and ($words -contains $flag.Long -or ($flag.Short -and $words -contains $flag.Short))) {
+                continue
+            }
+            foreach ($flagname in @($flag.Long, $flag.Short)) {
+                if ($flagname -and $flagname -like "$wordToComplete*") {
+                    [System.Management.Automation.CompletionResult]::new($flagname, $flagname, 'ParameterName', $flag.Description)
+                }
+            }
+        }
+        return
+    }
+
+    if (-not $valueflag -and $commands.Count -gt 0) {
+        foreach ($command in $commands) {
+            if ($command.Name -like "$wordToComplete*") {
+                [System.Management.Automation.CompletionResult]::new($command.Name, $command.Name, 'ParameterValue', $command.Description)
+            }
+        }
+        return
+    }
+
+    if (-not $valueflag -and $values.Count -gt 0) {
+        foreach ($value in $values) {
+            if ($value -like "$wordToComplete*") {
+                [System.Management.Automation.CompletionResult]::new($value, $value, 'ParameterValue', $value)
+            }
+        }
+        return
+    }
+
+    Get-ChildItem -Path "$wordToComplete*" -ErrorAction SilentlyContinue | ForEach-Object {
+        [System.Management.Automation.CompletionResult]::new($_.Name, $_.Name, 'ProviderItem', $_.Name)
+    }
+}
+`)
+	return b.String()
+}
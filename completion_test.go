@@ -0,0 +1,106 @@
+package argumentative
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+func completionFlags() *Flags {
+	flags := &Flags{}
+	flags.Flags().AddBool("verbose", "v", "Verbose output")
+	flags.Flags().AddString("config", "c", false, "", "Config file")
+	flags.Flags().AddStringSlice("include", "I", false, nil, "Include path [repeatable]")
+	flags.Flags().AddCount("debug", "", 0, "Increase debug level")
+	flags.Flags().AddNegatableBool("color", "", true, "Colored output")
+	deploy := flags.Flags().AddCommand("deploy", "Deploy a service")
+	deploy.AddString("env", "e", true, "", "Target environment")
+	deploy.AddBool("dry-run", "", "Don't change anything")
+	deploy.AddPositional("service", true, "", "Service to deploy")
+	status := flags.Flags().AddCommand("status", "Show status")
+	status.AddChoice("format", "f", false, "table", []string{"json", "yaml", "table"}, "Output format")
+	status.AddPositionalChoice("scope", false, "", []string{"all", "running"}, "Services to show")
+	return flags
+}
+
+func assertGolden(t *testing.T, name string, result []byte) {
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, result, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	await, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(result, await) {
+		t.Errorf("Output differs from %s, got\n%s\n\nwant\n\n%s", path, result, await)
+	}
+}
+
+func TestWriteCompletion(t *testing.T) {
+	shells := map[string]string{
+		"bash":       "completion.bash.golden",
+		"zsh":        "completion.zsh.golden",
+		"fish":       "completion.fish.golden",
+		"powershell": "completion.ps1.golden",
+	}
+	for shell, golden := range shells {
+		var result bytes.Buffer
+		if err := completionFlags().WriteCompletion(&result, "tool", shell); err != nil {
+			t.Errorf("Error found for %s, got [%s], want nil", shell, err.Error())
+		}
+		assertGolden(t, golden, result.Bytes())
+	}
+}
+
+func TestBashCompletionQuoting(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash not found")
+	}
+	choices := []string{"it's $HOME `date`", "a,b c", `back\slash`}
+	flags := &Flags{}
+	flags.Flags().AddChoice("mode", "m", false, "", choices, "Mode")
+	flags.Flags().AddPositionalChoice("target", false, "", choices, "Target")
+	var script bytes.Buffer
+	if err := flags.WriteCompletion(&script, "tool", "bash"); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "tool.bash")
+	if err := os.WriteFile(path, script.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, words := range []string{`tool --mode ""`, `tool -m ""`, `tool ""`} {
+		complete := fmt.Sprintf(`source %s; COMP_WORDS=(%s); COMP_CWORD=$((${#COMP_WORDS[@]} - 1)); _tool; printf '%%s\n' "${COMPREPLY[@]}"`, path, words)
+		output, err := exec.Command("bash", "-c", complete).Output()
+		if err != nil {
+			t.Fatalf("Error found for %s, got [%s], want nil", words, err.Error())
+		}
+		result := strings.TrimSuffix(string(output), "\n")
+		await := strings.Join(choices, "\n")
+		if result != await {
+			t.Errorf("Wrong completion for %s, got [%s], want [%s]", words, result, await)
+		}
+	}
+}
+
+func TestWriteCompletionUnknownShell(t *testing.T) {
+	var result bytes.Buffer
+	err := completionFlags().WriteCompletion(&result, "tool", "tcsh")
+	await := "unknown shell tcsh for completion, use bash, zsh, fish or powershell"
+
+	if err == nil {
+		t.Errorf("No error found, got [%p], want pointer", err)
+	} else if err.Error() != await {
+		t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
+	}
+}
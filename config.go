@@ -0,0 +1,289 @@
+package argumentative
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// struct for a single key with its values read from a config file
+type configEntry struct {
+	key    string
+	values []string
+	line   int
+}
+
+// Error in a config file with the place it was found at
+type ConfigError struct {
+	Path string
+	Line int
+	// Key of the entry, empty for syntax errors
+	Key string
+	// Cause like an InvalidValueError for a value that can not be assigned
+	Err error
+}
+
+// Generate the error message like "myapp.conf:3: unknown key "verbse""
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("%s:%d: %s", e.Path, e.Line, e.Err)
+}
+
+// Get the cause of the error
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// Generate the error for a config value that can not be assigned to its flag
+func invalidConfigValue(path string, entry configEntry, value string, err error) error {
+	return &ConfigError{Path: path, Line: entry.line, Key: entry.key, Err: &InvalidValueError{Flag: entry.key, Value: value, Index: -1, Err: err}}
+}
+
+// Use the value of a string flag as path of a config file, read after the command line
+func (f *Flags) SetConfigFlag(longflag string) {
+	if _, ok := f.valueflags[longflag].(*StringFlag); !ok {
+		panic("argumentative: --" + longflag + " is not a string flag to read the config path from")
+	}
+	f.configflag = longflag
+}
+
+// Mark a flag as path, relative values from a config file are resolved against its directory
+func (f *Flags) SetPathFlag(longflag string) {
+	if _, ok := f.valueflags[longflag]; !ok {
+		panic("argumentative: no flag --" + longflag + " to mark as path")
+	}
+	f.pathflags[longflag] = true
+}
+
+// Entries of a config file, read by a set of flags or handed down to its subcommand
+type configFile struct {
+	path    string
+	entries []configEntry
+}
+
+// Get the entries of the "[command]" section with the section removed from their keys
+func (c *configFile) section(command string) *configFile {
+	if c == nil {
+		return nil
+	}
+	section := &configFile{path: c.path}
+	for _, entry := range c.entries {
+		if key, ok := strings.CutPrefix(entry.key, command+"."); ok {
+			entry.key = key
+			section.entries = append(section.entries, entry)
+		}
+	}
+	return section
+}
+
+// Fill all flags neither given on the command line nor by environment from the config file, read by
+// this set or the section handed down by the parent of a subcommand
+func (f *Flags) applyConfig() error {
+	if f.configflag != "" {
+		if path := *f.valueflags[f.configflag].(*StringFlag).Value; path != "" {
+			entries, err := readConfig(path)
+			if err == nil {
+				f.config = &configFile{path: path, entries: entries}
+			} else if !errors.Is(err, fs.ErrNotExist) || f.IsSet(f.configflag) {
+				// A missing config file at the default location is not an error
+				return err
+			}
+		}
+	}
+	if f.config == nil {
+		return nil
+	}
+
+	path := f.config.path
+	applied := make(map[string]bool)
+	for _, entry := range f.config.entries {
+		if !f.hasFlag(entry.key) {
+			// Sections of commands are applied by the chosen command
+			if command, _, ok := strings.Cut(entry.key, "."); ok && f.getCommand(command) != nil {
+				continue
+			}
+			return &ConfigError{Path: path, Line: entry.line, Key: entry.key, Err: fmt.Errorf("unknown key %q", entry.key)}
+		}
+		if f.IsSet(entry.key) && !applied[entry.key] {
+			continue
+		}
+		if err := f.setFromConfig(path, entry); err != nil {
+			return err
+		}
+		applied[entry.key] = true
+		f.sources[entry.key] = SourceConfig
+	}
+	return nil
+}
+
+// Assign the values of a single config entry to its flag
+func (f *Flags) setFromConfig(path string, entry configEntry) error {
+	if flag, ok := f.valueflags[entry.key]; ok {
+		slice, isSlice := flag.(sliceFlag)
+		if !isSlice && len(entry.values) != 1 {
+			return &ConfigError{Path: path, Line: entry.line, Key: entry.key, Err: fmt.Errorf("invalid value for %s: expected a single value", entry.key)}
+		}
+		// The first entry replaces the defaults of repeatable flags, further entries append
+		if isSlice && !f.IsSet(entry.key) {
+			slice.clear()
+		}
+		for _, value := range entry.values {
+			if f.pathflags[entry.key] && value != "" && !filepath.IsAbs(value) {
+				value = filepath.Join(filepath.Dir(path), value)
+			}
+			err := flag.set(value)
+			if err == nil {
+				err = f.runValidators(entry.key, value, flag.value())
+			}
+			if err != nil {
+				return invalidConfigValue(path, entry, value, err)
+			}
+		}
+		return nil
+	}
+
+	if len(entry.values) != 1 {
+		return &ConfigError{Path: path, Line: entry.line, Key: entry.key, Err: fmt.Errorf("invalid value for %s: expected a single value", entry.key)}
+	}
+	value := entry.values[0]
+	if flag, ok := f.boolflags[entry.key]; ok {
+		converted, err := parseBool(value)
+		if err != nil {
+			return invalidConfigValue(path, entry, value, conversionError(err, "boolean"))
+		}
+		*flag.Value = converted
+		return nil
+	}
+	if flag, ok := f.countflags[entry.key]; ok {
+		if err := flag.set(value); err != nil {
+			return invalidConfigValue(path, entry, value, err)
+		}
+		return nil
+	}
+	return &ConfigError{Path: path, Line: entry.line, Key: entry.key, Err: fmt.Errorf("unknown key %q", entry.key)}
+}
+
+// Read a config file, files ending in .json are JSON, all others key = value pairs
+func readConfig(path string) ([]configEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return parseJSONConfig(path, data)
+	}
+	return parseKeyValueConfig(path, data)
+}
+
+// Parse a flat JSON object, arrays are allowed as values of repeatable flags
+func parseJSONConfig(path string, data []byte) ([]configEntry, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	token, err := decoder.Token()
+	if err != nil || token != json.Delim('{') {
+		return nil, &ConfigError{Path: path, Line: lineAt(data, decoder.InputOffset()), Err: errors.New("expected JSON object")}
+	}
+
+	var entries []configEntry
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, &ConfigError{Path: path, Line: lineAt(data, decoder.InputOffset()), Err: err}
+		}
+		key := token.(string)
+		line := lineAt(data, decoder.InputOffset())
+		var value interface{}
+		if err := decoder.Decode(&value); err != nil {
+			return nil, &ConfigError{Path: path, Line: lineAt(data, decoder.InputOffset()), Err: err}
+		}
+		values, err := jsonConfigValues(value)
+		if err != nil {
+			return nil, &ConfigError{Path: path, Line: line, Key: key, Err: fmt.Errorf("invalid value for %s: %s", key, err)}
+		}
+		entries = append(entries, configEntry{key: key, values: values, line: line})
+	}
+	return entries, nil
+}
+
+// Convert a decoded JSON value into the strings the flags are set from
+func jsonConfigValues(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case string:
+		return []string{v}, nil
+	case json.Number:
+		return []string{v.String()}, nil
+	case bool:
+		return []string{strconv.FormatBool(v)}, nil
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, element := range v {
+			converted, err := jsonConfigValues(element)
+			if err != nil || len(converted) != 1 {
+				return nil, errors.New("expected a list of single values")
+			}
+			values = append(values, converted[0])
+		}
+		return values, nil
+	}
+	return nil, errors.New("expected string, number, boolean or list")
+}
+
+// Parse "key = value" lines, "[section]" prefixes the following keys with "section."
+func parseKeyValueConfig(path string, data []byte) ([]configEntry, error) {
+	var entries []configEntry
+	section := ""
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if line[0] == '[' && line[len(line)-1] == ']' {
+			section = strings.TrimSpace(line[1:len(line)-1]) + "."
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, &ConfigError{Path: path, Line: i + 1, Err: errors.New("expected key = value")}
+		}
+		value = strings.TrimSpace(value)
+		var values []string
+		// TOML style lists like ["a", "b"] for repeatable flags
+		if len(value) > 1 && value[0] == '[' && value[len(value)-1] == ']' {
+			for _, element := range strings.Split(value[1:len(value)-1], ",") {
+				if element = strings.TrimSpace(element); element != "" {
+					values = append(values, unquote(element))
+				}
+			}
+		} else {
+			values = []string{unquote(value)}
+		}
+		entries = append(entries, configEntry{key: section + strings.TrimSpace(key), values: values, line: i + 1})
+	}
+	return entries, nil
+}
+
+// Remove double or single quotes around a value
+func unquote(value string) string {
+	if len(value) > 1 && value[0] == '"' && value[len(value)-1] == '"' {
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			return unquoted
+		}
+	}
+	if len(value) > 1 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// Get the line number of a byte offset
+func lineAt(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
@@ -0,0 +1,234 @@
+package argumentative
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeConfig(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestJSONConfig(t *testing.T) {
+	path := writeConfig(t, "app.json", `{
+	"name": "fromconfig",
+	"port": 8080,
+	"verbose": true,
+	"tag": ["a", "b"],
+	"output": "out/result.txt"
+}`)
+	flags := &Flags{}
+	flags.Flags().AddString("config", "c", false, "", "Config file")
+	name := flags.Flags().AddString("name", "n", true, "", "Name")
+	port := flags.Flags().AddInt("port", "p", false, 80, "Port")
+	verbose := flags.Flags().AddBool("verbose", "v", "Verbose output")
+	tags := flags.Flags().AddStringSlice("tag", "t", false, nil, "Tags")
+	output := flags.Flags().AddString("output", "o", false, "", "Output file")
+	flags.Flags().SetConfigFlag("config")
+	flags.Flags().SetPathFlag("output")
+
+	err := flags.Parse([]string{"scriptname", "-c", path, "--port", "9090"})
+
+	if err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if *name != "fromconfig" || !*verbose {
+		t.Errorf("Wrong values from config, got [%s %t], want [%s %t]", *name, *verbose, "fromconfig", true)
+	}
+
+	if *port != 9090 {
+		t.Errorf("Command line does not take precedence, got [%d], want [%d]", *port, 9090)
+	}
+
+	if !reflect.DeepEqual(*tags, []string{"a", "b"}) {
+		t.Errorf("Wrong list from config, got [%v], want [%v]", *tags, []string{"a", "b"})
+	}
+
+	await := filepath.Join(filepath.Dir(path), "out", "result.txt")
+	if *output != await {
+		t.Errorf("Path not resolved relative to config, got [%s], want [%s]", *output, await)
+	}
+}
+
+func TestKeyValueConfig(t *testing.T) {
+	path := writeConfig(t, "app.conf", `# comment
+name = "from config"
+port=8080
+tag = one
+tag = two
+
+[db]
+host = 'localhost'
+`)
+	flags := &Flags{}
+	flags.Flags().AddString("config", "c", false, path, "Config file")
+	name := flags.Flags().AddString("name", "n", false, "", "Name")
+	port := flags.Flags().AddInt("port", "p", false, 80, "Port")
+	tags := flags.Flags().AddStringSlice("tag", "t", false, []string{"default"}, "Tags")
+	host := flags.Flags().AddString("db.host", "", false, "", "Database host")
+	flags.Flags().SetConfigFlag("config")
+	flags.Flags().SetEnv("port", "TEST_PORT")
+
+	t.Setenv("TEST_PORT", "7070")
+	err := flags.Parse([]string{"scriptname"})
+
+	if err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if *name != "from config" || *host != "localhost" {
+		t.Errorf("Wrong values from config, got [%s %s], want [%s %s]", *name, *host, "from config", "localhost")
+	}
+
+	if *port != 7070 {
+		t.Errorf("Environment does not take precedence, got [%d], want [%d]", *port, 7070)
+	}
+
+	if !reflect.DeepEqual(*tags, []string{"one", "two"}) {
+		t.Errorf("Repeated keys not collected, got [%v], want [%v]", *tags, []string{"one", "two"})
+	}
+}
+
+func TestConfigCommands(t *testing.T) {
+	path := writeConfig(t, "app.conf", "port = 8080\n\n[deploy]\nenv = prod\n\n[status]\nformat = json\n")
+	flags := &Flags{}
+	flags.Flags().AddString("config", "c", false, path, "Config file")
+	port := flags.Flags().AddInt("port", "p", false, 80, "Port")
+	flags.Flags().SetConfigFlag("config")
+	deploy := flags.Flags().AddCommand("deploy", "Deploy a service")
+	env := deploy.AddString("env", "e", true, "", "Target environment")
+	status := flags.Flags().AddCommand("status", "Show status")
+	status.AddString("format", "f", false, "table", "Output format")
+
+	err := flags.Parse([]string{"tool", "deploy"})
+
+	if err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if *port != 8080 || *env != "prod" || deploy.Source("env") != SourceConfig {
+		t.Errorf("Wrong values from config, got [%d %s %s], want [8080 prod config]", *port, *env, deploy.Source("env"))
+	}
+
+	err = flags.Parse([]string{"tool", "deploy", "--env", "dev"})
+
+	if err != nil || *env != "dev" {
+		t.Errorf("Command line does not take precedence, got [%v %s], want [nil dev]", err, *env)
+	}
+
+	path = writeConfig(t, "app.conf", "[deploy]\nenvironment = prod\n")
+	err = flags.Parse([]string{"tool", "-c", path, "deploy"})
+	await := path + ":2: unknown key \"environment\""
+
+	if err == nil {
+		t.Errorf("No error found, got [%p], want pointer", err)
+	} else if err.Error() != await {
+		t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
+	}
+
+	path = writeConfig(t, "app.conf", "[destroy]\nforce = true\n")
+	err = flags.Parse([]string{"tool", "-c", path, "deploy", "-e", "prod"})
+	await = path + ":2: unknown key \"destroy.force\""
+
+	if err == nil {
+		t.Errorf("No error found, got [%p], want pointer", err)
+	} else if err.Error() != await {
+		t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
+	}
+}
+
+func TestConfigErrors(t *testing.T) {
+	flags := &Flags{}
+	config := flags.Flags().AddString("config", "c", false, filepath.Join(t.TempDir(), "missing.conf"), "Config file")
+	flags.Flags().AddInt("port", "p", false, 80, "Port")
+	flags.Flags().SetConfigFlag("config")
+
+	err := flags.Parse([]string{"scriptname"})
+
+	if err != nil {
+		t.Errorf("Missing default config is an error, got [%s], want nil", err.Error())
+	}
+
+	err = flags.Parse([]string{"scriptname", "-c", *config})
+
+	if err == nil {
+		t.Errorf("No error found for missing config, got [%p], want pointer", err)
+	}
+
+	path := writeConfig(t, "app.conf", "port = 80\n\nverbose = true\n")
+	err = flags.Parse([]string{"scriptname", "-c", path})
+	await := path + ":3: unknown key \"verbose\""
+
+	if err == nil {
+		t.Errorf("No error found, got [%p], want pointer", err)
+	} else if err.Error() != await {
+		t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
+	}
+
+	path = writeConfig(t, "app.json", "{\n  \"port\": \"http\"\n}")
+	err = flags.Parse([]string{"scriptname", "-c", path})
+	await = path + ":2: invalid value \"http\" for --port: expected integer"
+
+	if err == nil {
+		t.Errorf("No error found, got [%p], want pointer", err)
+	} else if err.Error() != await {
+		t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
+	}
+
+	path = writeConfig(t, "app.conf", "port\n")
+	err = flags.Parse([]string{"scriptname", "-c", path})
+	await = path + ":1: expected key = value"
+
+	if err == nil {
+		t.Errorf("No error found, got [%p], want pointer", err)
+	} else if err.Error() != await {
+		t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
+	}
+}
+
+func TestConfigErrorTypes(t *testing.T) {
+	flags := &Flags{}
+	flags.Flags().AddString("config", "c", false, "", "Config file")
+	verbose := flags.Flags().AddCount("verbose", "v", 3, "Verbose output")
+	flags.Flags().SetConfigFlag("config")
+
+	path := writeConfig(t, "app.conf", "verbose = 2\n")
+	if err := flags.Parse([]string{"scriptname", "-c", path}); err != nil || *verbose != 2 {
+		t.Errorf("Wrong count from config, got [%v %d], want [nil 2]", err, *verbose)
+	}
+
+	path = writeConfig(t, "app.conf", "verbose = 99\n")
+	if err := flags.Parse([]string{"scriptname", "-c", path}); err != nil || *verbose != 3 {
+		t.Errorf("Count from config not capped, got [%v %d], want [nil 3]", err, *verbose)
+	}
+
+	path = writeConfig(t, "app.conf", "# counts\nverbose = -1\n")
+	err := flags.Parse([]string{"scriptname", "-c", path})
+	await := path + ":2: invalid value \"-1\" for --verbose: must not be negative"
+	if err == nil || err.Error() != await {
+		t.Errorf("Wrong error message, got [%v], want [%s]", err, await)
+	}
+
+	var config *ConfigError
+	var invalid *InvalidValueError
+	if !errors.As(err, &config) || config.Path != path || config.Line != 2 || config.Key != "verbose" {
+		t.Errorf("No ConfigError with place, got [%v]", err)
+	}
+	if !errors.As(err, &invalid) || invalid.Flag != "verbose" || invalid.Value != "-1" {
+		t.Errorf("No InvalidValueError wrapped, got [%v]", err)
+	}
+
+	path = writeConfig(t, "app.conf", "verbose\n")
+	err = flags.Parse([]string{"scriptname", "-c", path})
+	if !errors.As(err, &config) || config.Line != 1 || config.Key != "" {
+		t.Errorf("No ConfigError for syntax error, got [%v]", err)
+	}
+}
@@ -0,0 +1,88 @@
+package argumentative
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// struct for a single configured flag that counts its occurrences
+type CountFlag struct {
+	Longflag    string
+	Shortflag   string
+	Description string
+	Env         string
+	Maximum     int
+	Value       *int
+}
+
+// Factory to generate a new count flag, a maximum of 0 means unlimited
+func NewCountFlag(longflag string, shortflag string, maximum int, description string) CountFlag {
+	flag := CountFlag{
+		Longflag:    longflag,
+		Shortflag:   shortflag,
+		Description: description,
+		Maximum:     maximum,
+		Value:       new(int),
+	}
+	*flag.Value = 0
+
+	return flag
+}
+
+// Restore the value to zero
+func (f *CountFlag) reset() {
+	*f.Value = 0
+}
+
+// Copy the definition with its own value storage
+func (f CountFlag) clone() CountFlag {
+	f.Value = new(int)
+	f.reset()
+	return f
+}
+
+// Count one more occurrence, stop at the maximum
+func (f *CountFlag) increment() {
+	if f.Maximum == 0 || *f.Value < f.Maximum {
+		*f.Value += 1
+	}
+}
+
+// Assign a number of occurrences given as a value, like from the environment or a config file,
+// capped at the maximum like repeated flags on the command line
+func (f *CountFlag) set(value string) error {
+	count, err := strconv.Atoi(value)
+	if err != nil {
+		return conversionError(err, "integer")
+	}
+	if count < 0 {
+		return fmt.Errorf("must not be negative")
+	}
+	if f.Maximum > 0 && count > f.Maximum {
+		count = f.Maximum
+	}
+	*f.Value = count
+	return nil
+}
+
+// Collect the metadata used for help, documentation and completion
+func (f *CountFlag) info() flagInfo {
+	return flagInfo{
+		longflag:    f.Longflag,
+		shortflag:   f.Shortflag,
+		description: f.Description,
+		typename:    "count",
+		env:         f.Env,
+		repeatable:  true,
+	}
+}
+
+// Generate the string for the long description
+func (f *CountFlag) GetLongDescription() string {
+	return longDescription(f.info())
+}
+
+// Generate the string for a short description in the 'Usage:' line
+func (f *CountFlag) GetShortDescription() string {
+	return shortDescription(f.info())
+}
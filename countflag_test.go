@@ -0,0 +1,61 @@
+package argumentative
+
+import "testing"
+
+func TestNewCountFlag(t *testing.T) {
+	flag := NewCountFlag("longname", "s", 3, "description")
+
+	if flag.Longflag != "longname" {
+		t.Errorf("Longflag assignment wrong, got [%s], want [%s]", flag.Longflag, "longname")
+	}
+
+	if flag.Shortflag != "s" {
+		t.Errorf("Shortflag assignment wrong, got [%s], want [%s]", flag.Shortflag, "s")
+	}
+
+	if flag.Maximum != 3 {
+		t.Errorf("Maximum assignment wrong, got [%d], want [%d]", flag.Maximum, 3)
+	}
+
+	if *flag.Value != 0 {
+		t.Errorf("Initial value wrong, got [%d], want [%d]", *flag.Value, 0)
+	}
+}
+
+func TestIncrementCount(t *testing.T) {
+	flag := NewCountFlag("longname", "s", 2, "description")
+	flag.increment()
+	flag.increment()
+	flag.increment()
+
+	if *flag.Value != 2 {
+		t.Errorf("Counting beyond maximum, got [%d], want [%d]", *flag.Value, 2)
+	}
+
+	flag = NewCountFlag("longname", "s", 0, "description")
+	for i := 0; i < 10; i++ {
+		flag.increment()
+	}
+
+	if *flag.Value != 10 {
+		t.Errorf("Counting without maximum wrong, got [%d], want [%d]", *flag.Value, 10)
+	}
+}
+
+func TestGetShortCountDescription(t *testing.T) {
+	flag := NewCountFlag("longname", "s", 0, "description")
+	result := flag.GetShortDescription()
+	await := " [-s]..." // @todo: remove space
+
+	if result != await {
+		t.Errorf("Generation of short description failed, got [%s], want [%s]", result, await)
+	}
+
+	flag = NewCountFlag("longname", "", 0, "description")
+	result = flag.GetShortDescription()
+	await = " [--longname]..." // @todo remove space
+
+	if result != await {
+		t.Errorf("Generation of short description no short name failed, got [%s], want [%s]", result, await)
+	}
+}
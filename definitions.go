@@ -0,0 +1,100 @@
+package argumentative
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Check the names of a new flag, mistakes in the definition panic like a redefinition in the flag package
+func (f *Flags) checkFlag(longflag string, shortflag string) {
+	if !validName(longflag) {
+		panic("argumentative: invalid flag name " + strconv.Quote(longflag) + ", use letters, digits and inner dashes")
+	}
+	if f.hasFlag(longflag) {
+		panic("argumentative: flag --" + longflag + " is defined twice")
+	}
+	// "--no-" in front of the name of a negatable bool switches it off
+	if negated, ok := strings.CutPrefix(longflag, "no-"); ok {
+		if flag, ok := f.boolflags[negated]; ok && flag.Negatable {
+			panic("argumentative: flag --" + longflag + " is the negation of --" + negated)
+		}
+	}
+	// Flags and positional arguments share their names in validators, IsSet, Source and Value
+	if f.positional(longflag) != nil {
+		panic("argumentative: flag --" + longflag + " has the name of a positional argument")
+	}
+	if shortflag == "" {
+		return
+	}
+	if len(shortflag) != 1 || !validShort(shortflag[0]) {
+		panic("argumentative: invalid short flag " + strconv.Quote(shortflag) + " of --" + longflag + ", use a single letter or digit")
+	}
+	if other, ok := f.shortflags[shortflag[0]]; ok {
+		panic("argumentative: short flag -" + shortflag + " of --" + longflag + " is already used by --" + other)
+	}
+}
+
+// Register a positional argument after checking its name and its place after the other ones
+func (f *Flags) addPositional(positional positionalArg) {
+	name := positional.info().name
+	if !validName(name) {
+		panic("argumentative: invalid positional argument name " + strconv.Quote(name) + ", use letters, digits and inner dashes")
+	}
+	if f.positional(name) != nil {
+		panic("argumentative: positional argument " + name + " is defined twice")
+	}
+	if f.hasFlag(name) {
+		panic("argumentative: positional argument " + name + " has the name of a flag")
+	}
+	if len(f.commands) > 0 {
+		panic("argumentative: positional argument " + name + " can not be used together with commands")
+	}
+	if min, _ := positional.bounds(); min > 0 {
+		for _, previous := range f.positionals {
+			if previousMin, _ := previous.bounds(); previousMin == 0 {
+				panic("argumentative: required positional argument " + name + " follows optional " + previous.info().name)
+			}
+		}
+	}
+	f.positionals = append(f.positionals, positional)
+}
+
+// Check the name of a subcommand
+func (f *Flags) checkCommand(name string) {
+	if !validName(name) {
+		panic("argumentative: invalid command name " + strconv.Quote(name) + ", use letters, digits and inner dashes")
+	}
+	// Every argument that is not a flag is taken as the command, a positional argument would never get a value
+	if len(f.positionals) > 0 {
+		panic("argumentative: command " + name + " can not be used together with positional arguments")
+	}
+	for _, command := range f.commands {
+		if command.Name == name {
+			panic("argumentative: command " + name + " is defined twice")
+		}
+	}
+}
+
+// Check if a name is usable on the command line, not empty, not starting with a dash and
+// without blanks, "=" or other punctuation
+func validName(name string) bool {
+	if name == "" || name[0] == '-' {
+		return false
+	}
+	for _, r := range name {
+		if !isNameRune(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// Check if a rune may be part of a name, "." separates the names of nested structs
+func isNameRune(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_.", r)
+}
+
+// Check if a character can be a short flag
+func validShort(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
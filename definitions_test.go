@@ -0,0 +1,111 @@
+package argumentative
+
+import (
+	"testing"
+)
+
+func TestDefinitionPanics(t *testing.T) {
+	tests := map[string]func(flags *Flags){
+		"duplicate long name":   func(flags *Flags) { flags.AddString("name", "", false, "", ""); flags.AddBool("name", "", "") },
+		"duplicate short name":  func(flags *Flags) { flags.AddString("name", "n", false, "", ""); flags.AddCount("number", "n", 0, "") },
+		"multi character short": func(flags *Flags) { flags.AddInt("port", "po", false, 0, "") },
+		"dash as short":         func(flags *Flags) { flags.AddBool("verbose", "-", "") },
+		"empty long name":       func(flags *Flags) { flags.AddBool("", "v", "") },
+		"leading dash":          func(flags *Flags) { flags.AddBool("--verbose", "", "") },
+		"blank in name":         func(flags *Flags) { flags.AddString("out file", "", false, "", "") },
+		"equal sign in name":    func(flags *Flags) { flags.AddString("out=file", "", false, "", "") },
+		"duplicate positional": func(flags *Flags) {
+			flags.AddPositional("file", false, "", "")
+			flags.AddPositional("file", false, "", "")
+		},
+		"required after optional": func(flags *Flags) {
+			flags.AddPositional("source", false, "", "")
+			flags.AddPositional("target", true, "", "")
+		},
+		"required after optional list": func(flags *Flags) {
+			flags.AddPositionalList("files", "*", nil, "")
+			flags.AddPositionalList("target", "1", nil, "")
+		},
+		"flag named like positional": func(flags *Flags) {
+			flags.AddPositional("file", false, "", "")
+			flags.AddString("file", "", false, "", "")
+		},
+		"positional named like flag": func(flags *Flags) {
+			flags.AddString("file", "", false, "", "")
+			flags.AddPositional("file", false, "", "")
+		},
+		"flag named like negation": func(flags *Flags) {
+			flags.AddNegatableBool("color", "", true, "")
+			flags.AddBool("no-color", "", "")
+		},
+		"negation named like flag": func(flags *Flags) {
+			flags.AddString("no-color", "", false, "", "")
+			flags.AddNegatableBool("color", "", true, "")
+		},
+		"positional before command": func(flags *Flags) { flags.AddPositional("file", false, "", ""); flags.AddCommand("deploy", "") },
+		"positional after command":  func(flags *Flags) { flags.AddCommand("deploy", ""); flags.AddPositional("file", false, "", "") },
+		"duplicate command":         func(flags *Flags) { flags.AddCommand("deploy", ""); flags.AddCommand("deploy", "") },
+		"invalid command":           func(flags *Flags) { flags.AddCommand("-deploy", "") },
+	}
+	for name, test := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("No panic for %s", name)
+				}
+			}()
+			flags := &Flags{}
+			test(flags.Flags())
+		}()
+	}
+}
+
+func TestDefinitionMessages(t *testing.T) {
+	tests := []struct {
+		define func(flags *Flags)
+		await  string
+	}{
+		{func(flags *Flags) { flags.AddString("name", "", false, "", ""); flags.AddBool("name", "", "") },
+			"argumentative: flag --name is defined twice"},
+		{func(flags *Flags) { flags.AddString("name", "n", false, "", ""); flags.AddCount("number", "n", 0, "") },
+			"argumentative: short flag -n of --number is already used by --name"},
+		{func(flags *Flags) { flags.AddInt("port", "po", false, 0, "") },
+			`argumentative: invalid short flag "po" of --port, use a single letter or digit`},
+		{func(flags *Flags) { flags.AddString("out file", "", false, "", "") },
+			`argumentative: invalid flag name "out file", use letters, digits and inner dashes`},
+		{func(flags *Flags) { flags.AddNegatableBool("color", "", true, ""); flags.AddBool("no-color", "", "") },
+			"argumentative: flag --no-color is the negation of --color"},
+		{func(flags *Flags) { flags.AddBool("no-color", "", ""); flags.AddNegatableBool("color", "", true, "") },
+			"argumentative: negation --no-color of --color is already a flag"},
+		{func(flags *Flags) {
+			flags.AddPositional("source", false, "", "")
+			flags.AddPositional("target", true, "", "")
+		},
+			"argumentative: required positional argument target follows optional source"},
+	}
+	for _, test := range tests {
+		result := func() (message interface{}) {
+			defer func() { message = recover() }()
+			flags := &Flags{}
+			test.define(flags.Flags())
+			return nil
+		}()
+		if result != test.await {
+			t.Errorf("Wrong panic message, got [%v], want [%s]", result, test.await)
+		}
+	}
+}
+
+func TestValidDefinitions(t *testing.T) {
+	flags := &Flags{}
+	flags.Flags().AddBool("dry-run", "n", "Dry run")
+	flags.Flags().AddString("log_level", "L", false, "", "Log level")
+	flags.Flags().AddInt("server.port", "9", false, 0, "Port")
+	flags.Flags().AddPositional("source", true, "", "Source")
+	flags.Flags().AddPositionalList("targets", "+", nil, "Targets")
+	flags.Flags().AddPositional("mode", false, "", "Mode")
+
+	if err := flags.Parse([]string{"tool", "-n9", "8080", "a", "b"}); err != nil {
+		t.Errorf("Error found, got [%s], want nil", err)
+	}
+}
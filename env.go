@@ -0,0 +1,108 @@
+package argumentative
+
+import (
+	"os"
+	"strings"
+)
+
+// Bind a flag to an environment variable that is used if the flag is not given
+func (f *Flags) SetEnv(longflag string, name string) {
+	if flag, ok := f.valueflags[longflag]; ok {
+		flag.setEnv(name)
+	} else if flag, ok := f.boolflags[longflag]; ok {
+		flag.Env = name
+		f.boolflags[longflag] = flag
+	} else if flag, ok := f.countflags[longflag]; ok {
+		flag.Env = name
+		f.countflags[longflag] = flag
+	} else {
+		panic("argumentative: no flag --" + longflag + " to bind to environment")
+	}
+}
+
+// Bind all flags without an explicit variable to prefix + upper-snake longflag, the flags of
+// subcommands as well
+func (f *Flags) SetEnvPrefix(prefix string) {
+	f.envprefix = prefix
+	for _, command := range f.commands {
+		command.Flags.SetEnvPrefix(prefix)
+	}
+	for name, flag := range f.valueflags {
+		if flag.env() == "" {
+			flag.setEnv(f.envName(name))
+		}
+	}
+	for name, flag := range f.boolflags {
+		if flag.Env == "" {
+			flag.Env = f.envName(name)
+			f.boolflags[name] = flag
+		}
+	}
+	for name, flag := range f.countflags {
+		if flag.Env == "" {
+			flag.Env = f.envName(name)
+			f.countflags[name] = flag
+		}
+	}
+}
+
+// Derive the environment variable name of a flag from the prefix, like MYAPP_DRY_RUN
+func (f *Flags) envName(longflag string) string {
+	if f.envprefix == "" {
+		return ""
+	}
+	return strings.ToUpper(f.envprefix + strings.NewReplacer("-", "_", ".", "_").Replace(longflag))
+}
+
+// Fill all flags not given on the command line from their environment variables
+func (f *Flags) applyEnv() error {
+	errs := &errorList{all: f.allerrors}
+	for _, name := range f.order {
+		if f.IsSet(name) {
+			continue
+		}
+		if flag, ok := f.valueflags[name]; ok && flag.env() != "" {
+			if value, ok := os.LookupEnv(flag.env()); ok {
+				if slice, ok := flag.(sliceFlag); ok {
+					slice.clear()
+				}
+				err := flag.set(value)
+				if err == nil {
+					err = f.runValidators(name, value, flag.value())
+				}
+				if err != nil {
+					if errs.add(&InvalidValueError{Flag: name, Value: value, Index: -1, Env: flag.env(), Err: err}) {
+						return errs.err()
+					}
+					continue
+				}
+				f.sources[name] = SourceEnv
+			}
+		}
+		if flag, ok := f.boolflags[name]; ok && flag.Env != "" {
+			if value, ok := os.LookupEnv(flag.Env); ok {
+				converted, err := parseBool(value)
+				if err != nil {
+					if errs.add(&InvalidValueError{Flag: name, Value: value, Index: -1, Env: flag.Env, Err: conversionError(err, "boolean")}) {
+						return errs.err()
+					}
+					continue
+				}
+				*flag.Value = converted
+				f.sources[name] = SourceEnv
+			}
+		}
+		if flag, ok := f.countflags[name]; ok && flag.Env != "" {
+			if value, ok := os.LookupEnv(flag.Env); ok {
+				if err := flag.set(value); err != nil {
+					if errs.add(&InvalidValueError{Flag: name, Value: value, Index: -1, Env: flag.Env, Err: err}) {
+						return errs.err()
+					}
+					continue
+				}
+				f.sources[name] = SourceEnv
+			}
+		}
+	}
+	return errs.err()
+}
@@ -0,0 +1,152 @@
+package argumentative
+
+import "testing"
+
+func TestEnvFallback(t *testing.T) {
+	flags := &Flags{}
+	name := flags.Flags().AddString("name", "n", true, "", "Name")
+	region := flags.Flags().AddString("region", "r", false, "eu", "Region")
+	flags.Flags().SetEnv("name", "TEST_NAME")
+	flags.Flags().SetEnv("region", "TEST_REGION")
+
+	err := flags.Parse([]string{"scriptname"})
+	await := "required flag --name missing"
+
+	if err == nil {
+		t.Errorf("No error found, got [%p], want pointer", err)
+	} else if err.Error() != await {
+		t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
+	}
+
+	t.Setenv("TEST_NAME", "fromenv")
+	err = flags.Parse([]string{"scriptname"})
+
+	if err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if *name != "fromenv" {
+		t.Errorf("Required flag not satisfied from environment, got [%s], want [%s]", *name, "fromenv")
+	}
+
+	if *region != "eu" {
+		t.Errorf("Default not kept without environment, got [%s], want [%s]", *region, "eu")
+	}
+
+	t.Setenv("TEST_REGION", "us")
+	err = flags.Parse([]string{"scriptname", "--name", "fromcli"})
+
+	if err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if *name != "fromcli" {
+		t.Errorf("Command line does not take precedence, got [%s], want [%s]", *name, "fromcli")
+	}
+
+	if *region != "us" {
+		t.Errorf("Environment does not take precedence over default, got [%s], want [%s]", *region, "us")
+	}
+}
+
+func TestEnvPrefix(t *testing.T) {
+	flags := &Flags{}
+	port := flags.Flags().AddInt("port", "p", false, 80, "Port")
+	flags.Flags().SetEnvPrefix("MYAPP_")
+	dryrun := flags.Flags().AddBool("dry-run", "", "Dry run")
+	tags := flags.Flags().AddStringSlice("tag", "t", false, []string{"default"}, "Tags")
+	flags.Flags().SetSeparator("tag", ",")
+	flags.Flags().SetEnv("tag", "TAGS")
+
+	t.Setenv("MYAPP_PORT", "8080")
+	t.Setenv("MYAPP_DRY_RUN", "true")
+	t.Setenv("TAGS", "a,b")
+	err := flags.Parse([]string{"scriptname"})
+
+	if err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if *port != 8080 || !*dryrun {
+		t.Errorf("Wrong values from environment, got [%d %t], want [%d %t]", *port, *dryrun, 8080, true)
+	}
+
+	if len(*tags) != 2 || (*tags)[0] != "a" || (*tags)[1] != "b" {
+		t.Errorf("Wrong slice values from environment, got [%v], want [%v]", *tags, []string{"a", "b"})
+	}
+
+	t.Setenv("MYAPP_PORT", "http")
+	err = flags.Parse([]string{"scriptname"})
+	await := "invalid value \"http\" for --port from environment MYAPP_PORT: expected integer"
+
+	if err == nil {
+		t.Errorf("No error found, got [%p], want pointer", err)
+	} else if err.Error() != await {
+		t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
+	}
+
+	result := flags.valueflags["port"].GetLongDescription()
+	await = "-p, --port               Port (Default: 80) (Env: MYAPP_PORT)"
+
+	if result != await {
+		t.Errorf("Generation of long description failed, got [%s], want [%s]", result, await)
+	}
+}
+
+func TestEnvPrefixInCommands(t *testing.T) {
+	for _, before := range []bool{true, false} {
+		flags := &Flags{}
+		if before {
+			flags.Flags().SetEnvPrefix("APP_")
+		}
+		deploy := flags.Flags().AddCommand("deploy", "Deploy a service")
+		name := deploy.AddString("env-name", "", false, "dev", "Environment")
+		if !before {
+			flags.SetEnvPrefix("APP_")
+		}
+
+		t.Setenv("APP_ENV_NAME", "prod")
+		err := flags.Parse([]string{"tool", "deploy"})
+
+		if err != nil || *name != "prod" {
+			t.Errorf("Prefix not applied to subcommand, got [%v %s], want [nil prod]", err, *name)
+		}
+	}
+}
+
+func TestEnvCount(t *testing.T) {
+	flags := &Flags{}
+	flags.Flags().SetEnvPrefix("MYAPP_")
+	verbose := flags.Flags().AddCount("verbose", "v", 3, "Verbose output")
+	debug := flags.Flags().AddCount("debug", "d", 0, "Debug level")
+	flags.SetEnv("debug", "TEST_ENV_DEBUG")
+
+	t.Setenv("MYAPP_VERBOSE", "2")
+	t.Setenv("TEST_ENV_DEBUG", "5")
+	err := flags.Parse([]string{"tool"})
+
+	if err != nil || *verbose != 2 || *debug != 5 {
+		t.Errorf("Wrong counts from environment, got [%v %d %d], want [nil 2 5]", err, *verbose, *debug)
+	}
+
+	if err = flags.Parse([]string{"tool", "-v"}); err != nil || *verbose != 1 {
+		t.Errorf("Environment used although flag is given, got [%v %d], want [nil 1]", err, *verbose)
+	}
+
+	t.Setenv("MYAPP_VERBOSE", "99")
+	if err = flags.Parse([]string{"tool"}); err != nil || *verbose != 3 {
+		t.Errorf("Count from environment not capped, got [%v %d], want [nil 3]", err, *verbose)
+	}
+
+	tests := map[string]string{
+		"-1": `invalid value "-1" for --verbose from environment MYAPP_VERBOSE: must not be negative`,
+		"x":  `invalid value "x" for --verbose from environment MYAPP_VERBOSE: expected integer`,
+	}
+	for value, await := range tests {
+		t.Setenv("MYAPP_VERBOSE", value)
+		err = flags.Parse([]string{"tool"})
+		if err == nil || err.Error() != await {
+			t.Errorf("Wrong error message, got [%v], want [%s]", err, await)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package argumentative
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Error for a flag on the command line that is not defined
+type UnknownFlagError struct {
+	// Flag as written on the command line like "--name" or "-n"
+	Flag string
+	// Index of the argument in the arguments passed to Parse
+	Index int
+	// Similar flags that were probably meant
+	Suggestions []string
+}
+
+// Generate the error message
+func (e *UnknownFlagError) Error() string {
+	return "unknown flag " + e.Flag + didYouMean(e.Suggestions)
+}
+
+// Error for a command that is not defined
+type UnknownCommandError struct {
+	Command string
+	Index   int
+	// Similar commands that were probably meant
+	Suggestions []string
+}
+
+// Generate the error message
+func (e *UnknownCommandError) Error() string {
+	return "unknown command " + e.Command + didYouMean(e.Suggestions)
+}
+
+// Error for a flag that takes a value but is the last argument
+type MissingValueError struct {
+	// Long name of the flag
+	Flag  string
+	Index int
+}
+
+// Generate the error message
+func (e *MissingValueError) Error() string {
+	return fmt.Sprintf("flag --%s requires a value", e.Flag)
+}
+
+// Error for a required flag, positional argument or command without a value
+type MissingRequiredError struct {
+	// Kind of the missing argument, "flag", "positional argument" or "command"
+	Kind string
+	// Long name of the flag or name of the positional argument, empty for commands
+	Name string
+}
+
+// Generate the error message
+func (e *MissingRequiredError) Error() string {
+	switch e.Kind {
+	case "flag":
+		return fmt.Sprintf("required flag --%s missing", e.Name)
+	case "positional argument":
+		return fmt.Sprintf("required positional argument [%s] missing", e.Name)
+	}
+	return "required " + e.Kind + " missing"
+}
+
+// Error for a value that can not be assigned to its flag
+type InvalidValueError struct {
+	// Long name of the flag
+	Flag  string
+	Value string
+	// Index of the argument, -1 if the value is from the environment, a config file or a default
+	Index int
+	// Environment variable the value was read from, empty for the command line
+	Env string
+	// Set if Flag is the name of a positional argument
+	Positional bool
+	Err        error
+}
+
+// Generate the error message
+func (e *InvalidValueError) Error() string {
+	if e.Positional {
+		return fmt.Sprintf("invalid value %q for [%s]: %s", e.Value, e.Flag, e.Err)
+	}
+	if e.Env != "" {
+		return fmt.Sprintf("invalid value %q for --%s from environment %s: %s", e.Value, e.Flag, e.Env, e.Err)
+	}
+	return fmt.Sprintf("invalid value %q for --%s: %s", e.Value, e.Flag, e.Err)
+}
+
+// Get the conversion error of the value
+func (e *InvalidValueError) Unwrap() error {
+	return e.Err
+}
+
+// Error for a value left over after all positional arguments are filled
+type TooManyPositionalsError struct {
+	Value string
+	Index int
+}
+
+// Generate the error message
+func (e *TooManyPositionalsError) Error() string {
+	return "unknown positional argument " + e.Value
+}
+
+// Error for a positional argument list with fewer or more values than it takes
+type PositionalCountError struct {
+	// Name of the positional argument
+	Name string
+	// Minimum and maximum number of values, a maximum of -1 is unlimited
+	Min   int
+	Max   int
+	Count int
+}
+
+// Generate the error message
+func (e *PositionalCountError) Error() string {
+	if e.Count < e.Min {
+		if e.Min == e.Max {
+			return fmt.Sprintf("positional argument [%s] requires %s, got %d", e.Name, countValues(e.Min), e.Count)
+		}
+		return fmt.Sprintf("positional argument [%s] requires at least %s, got %d", e.Name, countValues(e.Min), e.Count)
+	}
+	return fmt.Sprintf("positional argument [%s] takes at most %s, got %d", e.Name, countValues(e.Max), e.Count)
+}
+
+// Error for a value given to a flag that does not take one, like "--verbose=3" for a count flag
+var errNoValue = errors.New("flag does not take a value")
+
+// Report all errors of a Parse instead of stopping at the first one, they are joined with errors.Join,
+// the setting applies to the subcommands as well
+func (f *Flags) SetAllErrors(all bool) {
+	f.allerrors = all
+	for _, command := range f.commands {
+		command.Flags.SetAllErrors(all)
+	}
+}
+
+// Collect the errors of a parse and stop at the first one unless all errors are reported
+type errorList struct {
+	errors []error
+	all    bool
+}
+
+// Record an error, report if parsing has to stop
+func (l *errorList) add(err error) bool {
+	if err == nil {
+		return false
+	}
+	l.errors = append(l.errors, err)
+	return !l.all
+}
+
+// Get the collected errors, a single error is returned as it is
+func (l *errorList) err() error {
+	if len(l.errors) == 1 {
+		return l.errors[0]
+	}
+	return errors.Join(l.errors...)
+}
@@ -0,0 +1,194 @@
+package argumentative
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUnknownFlagError(t *testing.T) {
+	flags := &Flags{}
+	flags.Flags().AddBool("verbose", "v", "Verbose output")
+
+	err := flags.Parse([]string{"tool", "-v", "--unknown"})
+	var unknown *UnknownFlagError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("No UnknownFlagError found, got [%v]", err)
+	}
+	if unknown.Flag != "--unknown" || unknown.Index != 2 {
+		t.Errorf("Wrong UnknownFlagError, got [%s %d], want [%s %d]", unknown.Flag, unknown.Index, "--unknown", 2)
+	}
+
+	err = flags.Parse([]string{"tool", "-vx"})
+	if !errors.As(err, &unknown) || unknown.Flag != "-x" || unknown.Index != 1 {
+		t.Errorf("Wrong UnknownFlagError for short flag, got [%v]", err)
+	}
+}
+
+func TestMissingValueError(t *testing.T) {
+	flags := &Flags{}
+	flags.Flags().AddString("test", "t", false, "", "Test option")
+
+	for _, args := range [][]string{{"tool", "--test"}, {"tool", "-t"}} {
+		err := flags.Parse(args)
+		var missing *MissingValueError
+		if !errors.As(err, &missing) {
+			t.Fatalf("No MissingValueError found for %v, got [%v]", args, err)
+		}
+		if missing.Flag != "test" || missing.Index != 1 {
+			t.Errorf("Wrong MissingValueError, got [%s %d], want [%s %d]", missing.Flag, missing.Index, "test", 1)
+		}
+		await := "flag --test requires a value"
+		if err.Error() != await {
+			t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
+		}
+	}
+}
+
+func TestMissingRequiredError(t *testing.T) {
+	flags := &Flags{}
+	flags.Flags().AddString("name", "n", true, "", "Name")
+	flags.Flags().AddPositional("infile", true, "", "Input file")
+
+	err := flags.Parse([]string{"tool", "-n", "x"})
+	var missing *MissingRequiredError
+	if !errors.As(err, &missing) {
+		t.Fatalf("No MissingRequiredError found, got [%v]", err)
+	}
+	if missing.Kind != "positional argument" || missing.Name != "infile" {
+		t.Errorf("Wrong MissingRequiredError, got [%s %s], want [%s %s]", missing.Kind, missing.Name, "positional argument", "infile")
+	}
+}
+
+func TestInvalidValueError(t *testing.T) {
+	flags := &Flags{}
+	flags.Flags().AddInt("port", "p", false, 80, "Port")
+
+	err := flags.Parse([]string{"tool", "--port", "abc"})
+	var invalid *InvalidValueError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("No InvalidValueError found, got [%v]", err)
+	}
+	if invalid.Flag != "port" || invalid.Value != "abc" || invalid.Index != 1 {
+		t.Errorf("Wrong InvalidValueError, got [%s %s %d], want [%s %s %d]", invalid.Flag, invalid.Value, invalid.Index, "port", "abc", 1)
+	}
+
+	flags.SetEnv("port", "TEST_ERRORS_PORT")
+	t.Setenv("TEST_ERRORS_PORT", "abc")
+	err = flags.Parse([]string{"tool"})
+	if !errors.As(err, &invalid) || invalid.Env != "TEST_ERRORS_PORT" || invalid.Index != -1 {
+		t.Errorf("Wrong InvalidValueError from environment, got [%v]", err)
+	}
+}
+
+func TestTooManyPositionalsError(t *testing.T) {
+	flags := &Flags{}
+	flags.Flags().AddPositional("infile", true, "", "Input file")
+
+	err := flags.Parse([]string{"tool", "a", "b"})
+	var many *TooManyPositionalsError
+	if !errors.As(err, &many) {
+		t.Fatalf("No TooManyPositionalsError found, got [%v]", err)
+	}
+	if many.Value != "b" || many.Index != 2 {
+		t.Errorf("Wrong TooManyPositionalsError, got [%s %d], want [%s %d]", many.Value, many.Index, "b", 2)
+	}
+}
+
+func TestPositionalCountError(t *testing.T) {
+	flags := &Flags{}
+	flags.Flags().AddPositionalList("pair", "2", nil, "Pair")
+
+	err := flags.Parse([]string{"tool", "a"})
+	var count *PositionalCountError
+	if !errors.As(err, &count) {
+		t.Fatalf("No PositionalCountError found, got [%v]", err)
+	}
+	if count.Name != "pair" || count.Min != 2 || count.Max != 2 || count.Count != 1 {
+		t.Errorf("Wrong PositionalCountError, got [%s %d %d %d], want [pair 2 2 1]", count.Name, count.Min, count.Max, count.Count)
+	}
+}
+
+func TestPositionalErrorIndex(t *testing.T) {
+	flags := &Flags{}
+	flags.Flags().AddBool("verbose", "v", "Verbose output")
+	flags.Flags().AddPositionalChoice("mode", true, "", []string{"start", "stop"}, "Mode")
+	flags.Flags().AddPositionalList("names", "*", nil, "Names")
+	flags.AddValidator("names", MatchRegexp(`^[a-z]+$`))
+
+	var invalid *InvalidValueError
+	err := flags.Parse([]string{"tool", "-v", "restart"})
+	if !errors.As(err, &invalid) || invalid.Index != 2 {
+		t.Errorf("Wrong index of invalid choice, got [%v]", err)
+	}
+
+	err = flags.Parse([]string{"tool", "start", "web", "-v", "DB"})
+	if !errors.As(err, &invalid) || invalid.Index != 4 {
+		t.Errorf("Wrong index of invalid value, got [%v]", err)
+	}
+}
+
+func TestErrorIndexInCommand(t *testing.T) {
+	flags := &Flags{}
+	deploy := flags.Flags().AddCommand("deploy", "Deploy a service")
+	deploy.AddString("env", "e", false, "", "Target environment")
+
+	err := flags.Parse([]string{"tool", "deploy", "-e", "prod", "--force"})
+	var unknown *UnknownFlagError
+	if !errors.As(err, &unknown) || unknown.Index != 4 {
+		t.Errorf("Index not relative to all arguments, got [%v]", err)
+	}
+}
+
+func TestAllErrors(t *testing.T) {
+	flags := &Flags{}
+	flags.Flags().AddString("name", "n", true, "", "Name")
+	flags.Flags().AddInt("port", "p", false, 80, "Port")
+	flags.Flags().AddPositional("infile", false, "", "Input file")
+
+	args := []string{"tool", "--unknown", "-p", "abc", "a", "b"}
+	err := flags.Parse(args)
+	await := "unknown flag --unknown"
+	if err == nil || err.Error() != await {
+		t.Errorf("Parse did not stop at the first error, got [%v], want [%s]", err, await)
+	}
+
+	flags.SetAllErrors(true)
+	err = flags.Parse(args)
+	await = `unknown flag --unknown
+invalid value "abc" for --port: expected integer
+unknown positional argument b
+required flag --name missing`
+	if err == nil || err.Error() != await {
+		t.Errorf("Not all errors reported, got [%v], want [%s]", err, await)
+	}
+
+	var invalid *InvalidValueError
+	var missing *MissingRequiredError
+	if !errors.As(err, &invalid) || !errors.As(err, &missing) {
+		t.Errorf("Joined errors not usable with errors.As, got [%v]", err)
+	}
+
+	if err = flags.Parse([]string{"tool", "-n", "x", "a"}); err != nil {
+		t.Errorf("Error found, got [%s], want nil", err)
+	}
+}
+
+func TestAllErrorsInCommands(t *testing.T) {
+	for _, before := range []bool{true, false} {
+		flags := &Flags{}
+		if before {
+			flags.Flags().SetAllErrors(true)
+		}
+		deploy := flags.Flags().AddCommand("deploy", "Deploy")
+		deploy.AddBool("force", "f", "Force")
+		if !before {
+			flags.SetAllErrors(true)
+		}
+
+		err := flags.Parse([]string{"tool", "deploy", "--bogus", "--other"})
+		await := "unknown flag --bogus\nunknown flag --other"
+		if err == nil || err.Error() != await {
+			t.Errorf("Not all errors of subcommand reported, got [%v], want [%s]", err, await)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package argumentative
+
+import (
+	"strconv"
+)
+
+// struct for a single configured floating point flag
+type Float64Flag struct {
+	Longflag    string
+	Shortflag   string
+	Description string
+	Env         string
+	Required    bool
+	Default     float64
+	Value       *float64
+}
+
+// Factory to generate a new floating point flag
+func NewFloat64Flag(longflag string, shortflag string, required bool, defaultvalue float64, description string) Float64Flag {
+	flag := Float64Flag{
+		Longflag:    longflag,
+		Shortflag:   shortflag,
+		Description: description,
+		Required:    required,
+		Default:     defaultvalue,
+		Value:       new(float64),
+	}
+	*flag.Value = defaultvalue
+
+	return flag
+}
+
+// Restore the value to its default
+func (f *Float64Flag) reset() {
+	*f.Value = f.Default
+}
+
+// Put back a value taken from value() when a new one is rejected
+func (f *Float64Flag) restore(value interface{}) {
+	*f.Value = value.(float64)
+}
+
+// Copy the definition with its own value storage
+func (f *Float64Flag) clone() valueFlag {
+	flag := *f
+	flag.Value = new(float64)
+	flag.reset()
+	return &flag
+}
+
+// Convert and assign a value from the command line
+func (f *Float64Flag) set(value string) error {
+	converted, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return conversionError(err, "number")
+	}
+	*f.Value = converted
+	return nil
+}
+
+// Check if a required flag was neither given nor has a default
+func (f *Float64Flag) isMissing(given bool) bool {
+	return f.Required && !given && *f.Value == 0
+}
+
+// Get the converted value for validators
+func (f *Float64Flag) value() interface{} {
+	return *f.Value
+}
+
+// Get the name of the environment variable used as fallback
+func (f *Float64Flag) env() string {
+	return f.Env
+}
+
+// Set the name of the environment variable used as fallback
+func (f *Float64Flag) setEnv(name string) {
+	f.Env = name
+}
+
+// Collect the metadata used for help, documentation and completion
+func (f *Float64Flag) info() flagInfo {
+	defaultvalue := ""
+	if f.Default != 0 {
+		defaultvalue = strconv.FormatFloat(f.Default, 'g', -1, 64)
+	}
+	return flagInfo{
+		longflag:     f.Longflag,
+		shortflag:    f.Shortflag,
+		description:  f.Description,
+		typename:     "float64",
+		defaultvalue: defaultvalue,
+		env:          f.Env,
+		required:     f.Required,
+		repeatable:   false,
+		takesValue:   true,
+	}
+}
+
+// Generate the string for the long description
+func (f *Float64Flag) GetLongDescription() string {
+	return longDescription(f.info())
+}
+
+// Generate the string for a short description in the 'Usage:' line
+func (f *Float64Flag) GetShortDescription() string {
+	return shortDescription(f.info())
+}
@@ -0,0 +1,50 @@
+package argumentative
+
+import "testing"
+
+func TestNewFloat64Flag(t *testing.T) {
+	flag := NewFloat64Flag("longname", "s", true, 0.5, "description")
+
+	if flag.Longflag != "longname" {
+		t.Errorf("Longflag assignment wrong, got [%s], want [%s]", flag.Longflag, "longname")
+	}
+
+	if flag.Default != 0.5 {
+		t.Errorf("Default assignment wrong, got [%g], want [%g]", flag.Default, 0.5)
+	}
+
+	if *flag.Value != 0.5 {
+		t.Errorf("Assignment of default to value wrong, got [%g], want [%g]", *flag.Value, flag.Default)
+	}
+}
+
+func TestSetFloat64(t *testing.T) {
+	flag := NewFloat64Flag("longname", "s", false, 0, "description")
+
+	if err := flag.set("1e-3"); err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if *flag.Value != 0.001 {
+		t.Errorf("Conversion of value wrong, got [%g], want [%g]", *flag.Value, 0.001)
+	}
+
+	err := flag.set("fast")
+	await := "expected number"
+
+	if err == nil {
+		t.Errorf("No error found, got [%p], want pointer", err)
+	} else if err.Error() != await {
+		t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
+	}
+}
+
+func TestGetLongFloat64Description(t *testing.T) {
+	flag := NewFloat64Flag("longname", "s", false, 0.25, "description")
+	result := flag.GetLongDescription()
+	await := "-s, --longname           description (Default: 0.25)"
+
+	if result != await {
+		t.Errorf("Generation of long description failed, got [%s], want [%s]", result, await)
+	}
+}
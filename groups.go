@@ -0,0 +1,127 @@
+package argumentative
+
+import (
+	"strings"
+)
+
+// Kinds of flag groups
+const (
+	groupExclusive = iota
+	groupAtLeastOne
+	groupExactlyOne
+)
+
+// struct for a group of flags that are checked together
+type flagGroup struct {
+	kind  int
+	names []string
+}
+
+// Error for a group of flags that were used together although they exclude each other, or none of
+// them although one is required
+type GroupError struct {
+	// Long names of the flags that were used together, empty if none of the group was used
+	Conflicting []string
+	// Long names of all flags of the group
+	Group []string
+}
+
+// Generate the error message
+func (e *GroupError) Error() string {
+	if len(e.Conflicting) > 0 {
+		return "flags " + joinFlagNames(e.Conflicting, "and") + " can not be used together"
+	}
+	return "one of the flags " + joinFlagNames(e.Group, "or") + " is required"
+}
+
+// Declare flags that can not be used together
+func (f *Flags) AddExclusiveGroup(names ...string) {
+	f.addGroup(groupExclusive, names)
+}
+
+// Declare flags of which at least one has to be used
+func (f *Flags) AddAtLeastOneGroup(names ...string) {
+	f.addGroup(groupAtLeastOne, names)
+}
+
+// Declare flags of which exactly one has to be used
+func (f *Flags) AddExactlyOneGroup(names ...string) {
+	f.addGroup(groupExactlyOne, names)
+}
+
+// Add a group after checking that all its flags exist
+func (f *Flags) addGroup(kind int, names []string) {
+	if len(names) < 2 {
+		panic("argumentative: a group needs at least two flags")
+	}
+	for _, name := range names {
+		if !f.hasFlag(name) {
+			panic("argumentative: no flag --" + name + " for group")
+		}
+	}
+	f.groups = append(f.groups, flagGroup{kind: kind, names: names})
+}
+
+// Check the groups against the flags given on the command line, by environment or config file
+func (f *Flags) validateGroups() []error {
+	var errs []error
+	for _, group := range f.groups {
+		var used []string
+		for _, name := range group.names {
+			if f.isUsed(name) {
+				used = append(used, name)
+			}
+		}
+		if len(used) > 1 && group.kind != groupAtLeastOne {
+			errs = append(errs, &GroupError{Conflicting: used, Group: group.names})
+		} else if len(used) == 0 && group.kind != groupExclusive {
+			errs = append(errs, &GroupError{Group: group.names})
+		}
+	}
+	return errs
+}
+
+// Check if a flag counts as used in a group, a bool flag that is explicitly off like --no-color
+// or --json=false does not
+func (f *Flags) isUsed(name string) bool {
+	if flag, ok := f.boolflags[name]; ok && !*flag.Value {
+		return false
+	}
+	return f.IsSet(name)
+}
+
+// Get the group a flag is shown in within the usage line, the first one it belongs to
+func (f *Flags) groupOf(name string) *flagGroup {
+	for i, group := range f.groups {
+		for _, member := range group.names {
+			if member == name {
+				return &f.groups[i]
+			}
+		}
+	}
+	return nil
+}
+
+// Generate the usage of a group like "(-i ID | --name NAME)", optional groups in brackets
+func (f *Flags) groupSynopsis(group *flagGroup) string {
+	var alternatives []string
+	for _, name := range group.names {
+		alternatives = append(alternatives, flagUsage(f.flagInfo(name)))
+	}
+	if group.kind == groupExclusive {
+		return "[" + strings.Join(alternatives, " | ") + "]"
+	}
+	return "(" + strings.Join(alternatives, " | ") + ")"
+}
+
+// Join flag names like "--a, --b and --c"
+func joinFlagNames(names []string, conjunction string) string {
+	flags := make([]string, 0, len(names))
+	for _, name := range names {
+		flags = append(flags, "--"+name)
+	}
+	if len(flags) == 1 {
+		return flags[0]
+	}
+	return strings.Join(flags[:len(flags)-1], ", ") + " " + conjunction + " " + flags[len(flags)-1]
+}
@@ -0,0 +1,129 @@
+package argumentative
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func groupFlags() *Flags {
+	flags := &Flags{}
+	flags.Flags().AddBool("json", "", "JSON output")
+	flags.Flags().AddBool("yaml", "", "YAML output")
+	flags.Flags().AddBool("table", "", "Table output")
+	flags.Flags().AddString("id", "i", false, "", "ID of the service")
+	flags.Flags().AddString("name", "n", false, "", "Name of the service")
+	flags.Flags().AddExclusiveGroup("json", "yaml", "table")
+	flags.Flags().AddExactlyOneGroup("id", "name")
+	return flags
+}
+
+func TestExclusiveGroup(t *testing.T) {
+	flags := groupFlags()
+
+	if err := flags.Parse([]string{"tool", "--json", "--id", "1"}); err != nil {
+		t.Errorf("Error found, got [%s], want nil", err)
+	}
+
+	err := flags.Parse([]string{"tool", "--json", "--table", "--yaml", "--id", "1"})
+	await := "flags --json, --yaml and --table can not be used together"
+	if err == nil || err.Error() != await {
+		t.Errorf("Wrong error message, got [%v], want [%s]", err, await)
+	}
+
+	var group *GroupError
+	if !errors.As(err, &group) || !reflect.DeepEqual(group.Conflicting, []string{"json", "yaml", "table"}) {
+		t.Errorf("No GroupError naming the flags, got [%v]", err)
+	}
+
+	// Bool flags that are explicitly off are not used
+	if err := flags.Parse([]string{"tool", "--json=false", "--yaml", "--table=no", "--id", "1"}); err != nil {
+		t.Errorf("Switched off flag counted, got [%s], want nil", err)
+	}
+}
+
+func TestNegatedGroup(t *testing.T) {
+	flags := &Flags{}
+	flags.Flags().AddNegatableBool("color", "", false, "Colored output")
+	flags.Flags().AddString("theme", "", false, "", "Color theme")
+	flags.Flags().AddExactlyOneGroup("color", "theme")
+
+	err := flags.Parse([]string{"tool", "--no-color"})
+	await := "one of the flags --color or --theme is required"
+	if err == nil || err.Error() != await {
+		t.Errorf("Negated flag counted, got [%v], want [%s]", err, await)
+	}
+
+	if err := flags.Parse([]string{"tool", "--no-color", "--theme", "dark"}); err != nil {
+		t.Errorf("Negated flag counted as conflict, got [%s], want nil", err)
+	}
+}
+
+func TestExactlyOneGroup(t *testing.T) {
+	flags := groupFlags()
+
+	err := flags.Parse([]string{"tool"})
+	await := "one of the flags --id or --name is required"
+	if err == nil || err.Error() != await {
+		t.Errorf("Wrong error message, got [%v], want [%s]", err, await)
+	}
+
+	err = flags.Parse([]string{"tool", "-i", "1", "-n", "web"})
+	await = "flags --id and --name can not be used together"
+	if err == nil || err.Error() != await {
+		t.Errorf("Wrong error message, got [%v], want [%s]", err, await)
+	}
+}
+
+func TestAtLeastOneGroup(t *testing.T) {
+	flags := &Flags{}
+	flags.Flags().AddStringSlice("file", "f", false, nil, "File")
+	flags.Flags().AddStringSlice("dir", "d", false, nil, "Directory")
+	flags.Flags().AddAtLeastOneGroup("file", "dir")
+
+	if err := flags.Parse([]string{"tool", "-f", "a", "-d", "b"}); err != nil {
+		t.Errorf("Error found, got [%s], want nil", err)
+	}
+
+	t.Setenv("TEST_GROUP_DIR", "b")
+	flags.SetEnv("dir", "TEST_GROUP_DIR")
+	if err := flags.Parse([]string{"tool"}); err != nil {
+		t.Errorf("Flag from environment not counted, got [%s], want nil", err)
+	}
+}
+
+func TestGroupUsage(t *testing.T) {
+	flags := groupFlags()
+	flags.AddString("output", "o", false, "", "Output file")
+	flags.AddPositional("target", false, "", "Target")
+
+	result := flags.synopsis()
+	await := "[--json | --yaml | --table] (-i ID | -n NAME) [-o OUTPUT] [target]"
+	if result != await {
+		t.Errorf("Wrong usage of groups, got [%s], want [%s]", result, await)
+	}
+
+	result = flags.usageLine("tool", 34)
+	await = "Usage: tool [options]\n            (-i ID | -n NAME)\n            [target]"
+	if result != await {
+		t.Errorf("Wrong collapsed usage of groups, got [%s], want [%s]", result, await)
+	}
+}
+
+func TestGroupPanics(t *testing.T) {
+	tests := map[string]func(flags *Flags){
+		"single flag":  func(flags *Flags) { flags.AddBool("json", "", ""); flags.AddExclusiveGroup("json") },
+		"unknown flag": func(flags *Flags) { flags.AddBool("json", "", ""); flags.AddExclusiveGroup("json", "xml") },
+	}
+	for name, test := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("No panic for %s", name)
+				}
+			}()
+			flags := &Flags{}
+			test(flags.Flags())
+		}()
+	}
+}
@@ -0,0 +1,168 @@
+package argumentative
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Column the descriptions start in, unless longer names need more room
+	defaultColumn = 25
+	// Names longer than this put their description on the next line
+	maxColumn = 40
+	// Width used when neither SetWidth nor COLUMNS give one
+	defaultWidth = 80
+	// Descriptions are not squeezed into less room than this
+	minTextWidth = 20
+)
+
+// Set the width help text is wrapped at, 0 takes it from COLUMNS or falls back to 80
+func (f *Flags) SetWidth(width int) {
+	if width < 0 {
+		panic("argumentative: help width must not be negative")
+	}
+	f.width = width
+}
+
+// Get the width help text is wrapped at
+func (f *Flags) helpWidth() int {
+	if f.width > 0 {
+		return f.width
+	}
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	return defaultWidth
+}
+
+// Compute the column descriptions start in from the longest of the given names, names too long
+// for maxColumn get their description on the next line and leave the column as it is
+func helpColumn(names []string) int {
+	column := defaultColumn
+	for _, name := range names {
+		if len(name)+2 > column && len(name)+2 <= maxColumn {
+			column = len(name) + 2
+		}
+	}
+	return column
+}
+
+// Format a help entry with the names padded to column and the text wrapped at width,
+// a width of 0 does not wrap
+func formatEntry(names string, text string, column int, width int) string {
+	if text == "" {
+		return fmt.Sprintf("%-*s", column, names)
+	}
+	indent := strings.Repeat(" ", column)
+	output := fmt.Sprintf("%-*s", column, names)
+	// Names that do not leave room for a gap get the description on the next line
+	if len(names)+2 > column {
+		output = names + "\n" + indent
+	}
+	if width == 0 {
+		return output + text
+	}
+	return output + strings.Join(wrapText(text, width-column), "\n"+indent)
+}
+
+// Split text into lines of at most width characters, words longer than width keep a line of their own
+func wrapText(text string, width int) []string {
+	if width < minTextWidth {
+		width = minTextWidth
+	}
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	return append(lines, line)
+}
+
+// Generate the parts of the usage line, optional flags are collapsed into "[options]" if asked for,
+// groups of flags are shown like "(--id ID | --name NAME)"
+func (f *Flags) synopsisParts(collapse bool) []string {
+	var parts []string
+	collapsed := false
+	shown := make(map[*flagGroup]bool)
+	infos := f.flagInfos()
+	add := func(info flagInfo) {
+		// Groups are shown as a whole where their first flag would be
+		group := f.groupOf(info.longflag)
+		if group != nil && shown[group] {
+			return
+		}
+		required := info.required || (group != nil && group.kind != groupExclusive)
+		if collapse && !required {
+			if !collapsed {
+				parts = append(parts, "[options]")
+				collapsed = true
+			}
+			return
+		}
+		if group != nil {
+			shown[group] = true
+			parts = append(parts, f.groupSynopsis(group))
+			return
+		}
+		parts = append(parts, strings.TrimSpace(shortDescription(info)))
+	}
+	for _, info := range infos {
+		if !info.takesValue {
+			add(info)
+		}
+	}
+	for _, info := range infos {
+		if info.takesValue {
+			add(info)
+		}
+	}
+	for _, positional := range f.positionals {
+		parts = append(parts, strings.TrimSpace(positional.GetShortDescription()))
+	}
+	if len(f.commands) > 0 && f.requirecmd {
+		parts = append(parts, "command ...")
+	} else if len(f.commands) > 0 {
+		parts = append(parts, "[command ...]")
+	}
+	return parts
+}
+
+// Generate the usage line, collapsed to "[options]" and wrapped if it is wider than width
+func (f *Flags) usageLine(name string, width int) string {
+	prefix := "Usage: " + name
+	parts := f.synopsisParts(false)
+	line := strings.TrimSpace(prefix + " " + strings.Join(parts, " "))
+	if len(line) <= width {
+		return line
+	}
+
+	// Continuation lines line up after the name unless that leaves too little room
+	indent := strings.Repeat(" ", len(prefix)+1)
+	if len(indent) > width/2 {
+		indent = strings.Repeat(" ", 4)
+	}
+	line = prefix
+	length := len(prefix)
+	started := false
+	for _, part := range f.synopsisParts(true) {
+		// Every line holds at least one part, even if that part alone is too wide
+		if started && length+1+len(part) > width {
+			line += "\n" + indent + part
+			length = len(indent) + len(part)
+			continue
+		}
+		line += " " + part
+		length += 1 + len(part)
+		started = true
+	}
+	return line
+}
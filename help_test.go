@@ -0,0 +1,138 @@
+package argumentative
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHelpWidth(t *testing.T) {
+	flags := &Flags{}
+	flags.Flags()
+
+	t.Setenv("COLUMNS", "")
+	if result := flags.helpWidth(); result != 80 {
+		t.Errorf("Default help width wrong, got [%d], want [%d]", result, 80)
+	}
+
+	t.Setenv("COLUMNS", "120")
+	if result := flags.helpWidth(); result != 120 {
+		t.Errorf("Help width from COLUMNS wrong, got [%d], want [%d]", result, 120)
+	}
+
+	t.Setenv("COLUMNS", "wide")
+	if result := flags.helpWidth(); result != 80 {
+		t.Errorf("Invalid COLUMNS not ignored, got [%d], want [%d]", result, 80)
+	}
+
+	t.Setenv("COLUMNS", "120")
+	flags.SetWidth(60)
+	if result := flags.helpWidth(); result != 60 {
+		t.Errorf("Explicit help width not preferred, got [%d], want [%d]", result, 60)
+	}
+}
+
+func TestHelpColumn(t *testing.T) {
+	if result := helpColumn([]string{"-a, --all"}); result != 25 {
+		t.Errorf("Short names changed the column, got [%d], want [%d]", result, 25)
+	}
+
+	if result := helpColumn([]string{"-a, --all", "--a-rather-long-name"}); result != 25 {
+		t.Errorf("Names fitting the default column changed it, got [%d], want [%d]", result, 25)
+	}
+
+	if result := helpColumn([]string{"-a, --all", "-l, --a-much-longer-flag-name"}); result != 31 {
+		t.Errorf("Column not computed from the longest name, got [%d], want [%d]", result, 31)
+	}
+
+	if result := helpColumn([]string{"--" + strings.Repeat("x", 50)}); result != 25 {
+		t.Errorf("Name too long for the column widened it, got [%d], want [%d]", result, 25)
+	}
+
+	if result := helpColumn([]string{"-l, --a-much-longer-flag-name", "--" + strings.Repeat("x", 50)}); result != 31 {
+		t.Errorf("Name too long for the column counted, got [%d], want [%d]", result, 31)
+	}
+}
+
+func TestFormatEntry(t *testing.T) {
+	result := formatEntry("--name", "description", 10, 0)
+	await := "--name    description"
+	if result != await {
+		t.Errorf("Padding of names failed, got [%s], want [%s]", result, await)
+	}
+
+	result = formatEntry("--very-long-name", "description", 10, 0)
+	await = "--very-long-name\n          description"
+	if result != await {
+		t.Errorf("Long names not put on their own line, got [%s], want [%s]", result, await)
+	}
+
+	result = formatEntry("--name", "one two three four five six seven eight nine ten", 10, 40)
+	await = "--name    one two three four five six\n          seven eight nine ten"
+	if result != await {
+		t.Errorf("Wrapping with hanging indent failed, got [%s], want [%s]", result, await)
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	result := strings.Join(wrapText("a somewhatlongerwordthantheline b", 20), "|")
+	await := "a|somewhatlongerwordthantheline|b"
+	if result != await {
+		t.Errorf("Wrapping of long words failed, got [%s], want [%s]", result, await)
+	}
+
+	result = strings.Join(wrapText("one two three", 5), "|")
+	await = "one two three"
+	if result != await {
+		t.Errorf("Minimum text width not kept, got [%s], want [%s]", result, await)
+	}
+}
+
+func TestUsageLine(t *testing.T) {
+	flags := &Flags{}
+	flags.Flags().AddBool("verbose", "v", "Verbose output")
+	flags.Flags().AddBool("quiet", "q", "Quiet output")
+	flags.Flags().AddString("output", "o", false, "", "Output file")
+	flags.Flags().AddString("target", "t", true, "", "Target")
+	flags.Flags().AddPositional("infile", true, "", "Input file")
+
+	result := flags.usageLine("tool", 80)
+	await := "Usage: tool [-v] [-q] [-o OUTPUT] -t TARGET infile"
+	if result != await {
+		t.Errorf("Usage line changed although it fits, got [%s], want [%s]", result, await)
+	}
+
+	result = flags.usageLine("tool", 30)
+	await = "Usage: tool [options]\n            -t TARGET infile"
+	if result != await {
+		t.Errorf("Usage line not collapsed and wrapped, got [%s], want [%s]", result, await)
+	}
+}
+
+func TestUsageWrapping(t *testing.T) {
+	flags := &Flags{}
+	flags.Flags().AddBool("verbose", "v", "Print every step that is executed to the standard output")
+	flags.Flags().AddString("a-rather-long-option-name", "l", false, "", "Long option")
+	flags.Flags().AddPositional("infile", false, "", "Input file")
+	flags.SetWidth(50)
+
+	await := `title
+description
+
+Usage: title [options] [infile]
+
+Flags:
+-v, --verbose                    Print every step
+                                 that is executed to
+                                 the standard output
+
+Options:
+-l, --a-rather-long-option-name  Long option
+
+Positional arguments:
+infile                           Input file
+`
+
+	if result := flags.UsageString("title", "description", nil); result != await {
+		t.Errorf("Wrong wrapped Usage output, got\n%s\n\nwant\n\n%s", result, await)
+	}
+}
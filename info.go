@@ -0,0 +1,63 @@
+package argumentative
+
+import (
+	"sort"
+)
+
+// metadata of a single flag used for help, documentation and completion
+type flagInfo struct {
+	longflag     string
+	shortflag    string
+	description  string
+	typename     string
+	defaultvalue string
+	env          string
+	required     bool
+	repeatable   bool
+	takesValue   bool
+	negatable    bool
+	choices      []string
+}
+
+// metadata of a single positional argument used for documentation and completion
+type positionalInfo struct {
+	name         string
+	description  string
+	defaultvalue string
+	min          int
+	max          int
+	choices      []string
+}
+
+// Collect the metadata of all flags in the order they were added or sorted by long name
+func (f *Flags) flagInfos() []flagInfo {
+	infos := make([]flagInfo, 0, len(f.order))
+	for _, name := range f.order {
+		infos = append(infos, f.flagInfo(name))
+	}
+	if f.sorted {
+		sort.Slice(infos, func(i, j int) bool {
+			return infos[i].longflag < infos[j].longflag
+		})
+	}
+	return infos
+}
+
+// Collect the metadata of a single flag
+func (f *Flags) flagInfo(name string) flagInfo {
+	if flag, ok := f.boolflags[name]; ok {
+		return flag.info()
+	} else if flag, ok := f.countflags[name]; ok {
+		return flag.info()
+	}
+	return f.valueflags[name].info()
+}
+
+// Collect the metadata of all positional arguments in order
+func (f *Flags) positionalInfos() []positionalInfo {
+	infos := make([]positionalInfo, 0, len(f.positionals))
+	for _, positional := range f.positionals {
+		infos = append(infos, positional.info())
+	}
+	return infos
+}
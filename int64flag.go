@@ -0,0 +1,108 @@
+package argumentative
+
+import (
+	"strconv"
+)
+
+// struct for a single configured 64 bit integer flag
+type Int64Flag struct {
+	Longflag    string
+	Shortflag   string
+	Description string
+	Env         string
+	Required    bool
+	Default     int64
+	Value       *int64
+}
+
+// Factory to generate a new 64 bit integer flag
+func NewInt64Flag(longflag string, shortflag string, required bool, defaultvalue int64, description string) Int64Flag {
+	flag := Int64Flag{
+		Longflag:    longflag,
+		Shortflag:   shortflag,
+		Description: description,
+		Required:    required,
+		Default:     defaultvalue,
+		Value:       new(int64),
+	}
+	*flag.Value = defaultvalue
+
+	return flag
+}
+
+// Restore the value to its default
+func (f *Int64Flag) reset() {
+	*f.Value = f.Default
+}
+
+// Put back a value taken from value() when a new one is rejected
+func (f *Int64Flag) restore(value interface{}) {
+	*f.Value = value.(int64)
+}
+
+// Copy the definition with its own value storage
+func (f *Int64Flag) clone() valueFlag {
+	flag := *f
+	flag.Value = new(int64)
+	flag.reset()
+	return &flag
+}
+
+// Convert and assign a value from the command line
+func (f *Int64Flag) set(value string) error {
+	converted, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return conversionError(err, "integer")
+	}
+	*f.Value = converted
+	return nil
+}
+
+// Check if a required flag was neither given nor has a default
+func (f *Int64Flag) isMissing(given bool) bool {
+	return f.Required && !given && *f.Value == 0
+}
+
+// Get the converted value for validators
+func (f *Int64Flag) value() interface{} {
+	return *f.Value
+}
+
+// Get the name of the environment variable used as fallback
+func (f *Int64Flag) env() string {
+	return f.Env
+}
+
+// Set the name of the environment variable used as fallback
+func (f *Int64Flag) setEnv(name string) {
+	f.Env = name
+}
+
+// Collect the metadata used for help, documentation and completion
+func (f *Int64Flag) info() flagInfo {
+	defaultvalue := ""
+	if f.Default != 0 {
+		defaultvalue = strconv.FormatInt(f.Default, 10)
+	}
+	return flagInfo{
+		longflag:     f.Longflag,
+		shortflag:    f.Shortflag,
+		description:  f.Description,
+		typename:     "int64",
+		defaultvalue: defaultvalue,
+		env:          f.Env,
+		required:     f.Required,
+		repeatable:   false,
+		takesValue:   true,
+	}
+}
+
+// Generate the string for the long description
+func (f *Int64Flag) GetLongDescription() string {
+	return longDescription(f.info())
+}
+
+// Generate the string for a short description in the 'Usage:' line
+func (f *Int64Flag) GetShortDescription() string {
+	return shortDescription(f.info())
+}
@@ -0,0 +1,50 @@
+package argumentative
+
+import "testing"
+
+func TestNewInt64Flag(t *testing.T) {
+	flag := NewInt64Flag("longname", "s", true, 1<<40, "description")
+
+	if flag.Longflag != "longname" {
+		t.Errorf("Longflag assignment wrong, got [%s], want [%s]", flag.Longflag, "longname")
+	}
+
+	if flag.Default != 1<<40 {
+		t.Errorf("Default assignment wrong, got [%d], want [%d]", flag.Default, int64(1<<40))
+	}
+
+	if *flag.Value != 1<<40 {
+		t.Errorf("Assignment of default to value wrong, got [%d], want [%d]", *flag.Value, flag.Default)
+	}
+}
+
+func TestSetInt64(t *testing.T) {
+	flag := NewInt64Flag("longname", "s", false, 0, "description")
+
+	if err := flag.set("9223372036854775807"); err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if *flag.Value != 9223372036854775807 {
+		t.Errorf("Conversion of value wrong, got [%d], want [%d]", *flag.Value, int64(9223372036854775807))
+	}
+
+	err := flag.set("1.5")
+	await := "expected integer"
+
+	if err == nil {
+		t.Errorf("No error found, got [%p], want pointer", err)
+	} else if err.Error() != await {
+		t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
+	}
+}
+
+func TestGetLongInt64Description(t *testing.T) {
+	flag := NewInt64Flag("longname", "s", false, 1<<40, "description")
+	result := flag.GetLongDescription()
+	await := "-s, --longname           description (Default: 1099511627776)"
+
+	if result != await {
+		t.Errorf("Generation of long description failed, got [%s], want [%s]", result, await)
+	}
+}
@@ -0,0 +1,108 @@
+package argumentative
+
+import (
+	"strconv"
+)
+
+// struct for a single configured integer flag
+type IntFlag struct {
+	Longflag    string
+	Shortflag   string
+	Description string
+	Env         string
+	Required    bool
+	Default     int
+	Value       *int
+}
+
+// Factory to generate a new integer flag
+func NewIntFlag(longflag string, shortflag string, required bool, defaultvalue int, description string) IntFlag {
+	flag := IntFlag{
+		Longflag:    longflag,
+		Shortflag:   shortflag,
+		Description: description,
+		Required:    required,
+		Default:     defaultvalue,
+		Value:       new(int),
+	}
+	*flag.Value = defaultvalue
+
+	return flag
+}
+
+// Restore the value to its default
+func (f *IntFlag) reset() {
+	*f.Value = f.Default
+}
+
+// Put back a value taken from value() when a new one is rejected
+func (f *IntFlag) restore(value interface{}) {
+	*f.Value = value.(int)
+}
+
+// Copy the definition with its own value storage
+func (f *IntFlag) clone() valueFlag {
+	flag := *f
+	flag.Value = new(int)
+	flag.reset()
+	return &flag
+}
+
+// Convert and assign a value from the command line
+func (f *IntFlag) set(value string) error {
+	converted, err := strconv.ParseInt(value, 10, strconv.IntSize)
+	if err != nil {
+		return conversionError(err, "integer")
+	}
+	*f.Value = int(converted)
+	return nil
+}
+
+// Check if a required flag was neither given nor has a default
+func (f *IntFlag) isMissing(given bool) bool {
+	return f.Required && !given && *f.Value == 0
+}
+
+// Get the converted value for validators
+func (f *IntFlag) value() interface{} {
+	return *f.Value
+}
+
+// Get the name of the environment variable used as fallback
+func (f *IntFlag) env() string {
+	return f.Env
+}
+
+// Set the name of the environment variable used as fallback
+func (f *IntFlag) setEnv(name string) {
+	f.Env = name
+}
+
+// Collect the metadata used for help, documentation and completion
+func (f *IntFlag) info() flagInfo {
+	defaultvalue := ""
+	if f.Default != 0 {
+		defaultvalue = strconv.Itoa(f.Default)
+	}
+	return flagInfo{
+		longflag:     f.Longflag,
+		shortflag:    f.Shortflag,
+		description:  f.Description,
+		typename:     "int",
+		defaultvalue: defaultvalue,
+		env:          f.Env,
+		required:     f.Required,
+		repeatable:   false,
+		takesValue:   true,
+	}
+}
+
+// Generate the string for the long description
+func (f *IntFlag) GetLongDescription() string {
+	return longDescription(f.info())
+}
+
+// Generate the string for a short description in the 'Usage:' line
+func (f *IntFlag) GetShortDescription() string {
+	return shortDescription(f.info())
+}
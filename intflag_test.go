@@ -0,0 +1,93 @@
+package argumentative
+
+import "testing"
+
+func TestNewIntFlag(t *testing.T) {
+	flag := NewIntFlag("longname", "s", true, 42, "description")
+
+	if flag.Longflag != "longname" {
+		t.Errorf("Longflag assignment wrong, got [%s], want [%s]", flag.Longflag, "longname")
+	}
+
+	if flag.Shortflag != "s" {
+		t.Errorf("Shortflag assignment wrong, got [%s], want [%s]", flag.Shortflag, "s")
+	}
+
+	if flag.Default != 42 {
+		t.Errorf("Default assignment wrong, got [%d], want [%d]", flag.Default, 42)
+	}
+
+	if flag.Description != "description" {
+		t.Errorf("Description assignment wrong, got [%s], want [%s]", flag.Description, "description")
+	}
+
+	if *flag.Value != 42 {
+		t.Errorf("Assignment of default to value wrong, got [%d], want [%d]", *flag.Value, flag.Default)
+	}
+}
+
+func TestSetInt(t *testing.T) {
+	flag := NewIntFlag("longname", "s", false, 0, "description")
+
+	if err := flag.set("-17"); err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if *flag.Value != -17 {
+		t.Errorf("Conversion of value wrong, got [%d], want [%d]", *flag.Value, -17)
+	}
+
+	err := flag.set("abc")
+	await := "expected integer"
+
+	if err == nil {
+		t.Errorf("No error found, got [%p], want pointer", err)
+	} else if err.Error() != await {
+		t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
+	}
+
+	err = flag.set("99999999999999999999")
+	await = "value out of range"
+
+	if err == nil {
+		t.Errorf("No error found, got [%p], want pointer", err)
+	} else if err.Error() != await {
+		t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
+	}
+}
+
+func TestGetLongIntDescription(t *testing.T) {
+	flag := NewIntFlag("longname", "s", false, 42, "description")
+	result := flag.GetLongDescription()
+	await := "-s, --longname           description (Default: 42)"
+
+	if result != await {
+		t.Errorf("Generation of long description failed, got [%s], want [%s]", result, await)
+	}
+
+	flag = NewIntFlag("longname", "", false, 0, "description")
+	result = flag.GetLongDescription()
+	await = "--longname               description"
+
+	if result != await {
+		t.Errorf("Generation of long description without default failed, got [%s], want [%s]", result, await)
+	}
+}
+
+func TestGetShortIntDescription(t *testing.T) {
+	flag := NewIntFlag("longname", "s", true, 0, "description")
+	result := flag.GetShortDescription()
+	await := " -s LONGNAME" // @todo: remove space
+
+	if result != await {
+		t.Errorf("Generation of short description failed, got [%s], want [%s]", result, await)
+	}
+
+	flag = NewIntFlag("longname", "", false, 0, "description")
+	result = flag.GetShortDescription()
+	await = " [--longname LONGNAME]" // @todo remove space
+
+	if result != await {
+		t.Errorf("Generation of short description no required failed, got [%s], want [%s]", result, await)
+	}
+}
@@ -0,0 +1,128 @@
+package argumentative
+
+import (
+	"strconv"
+	"strings"
+)
+
+// struct for a single configured integer flag that collects every occurrence
+type IntSliceFlag struct {
+	Longflag    string
+	Shortflag   string
+	Description string
+	Env         string
+	Required    bool
+	Separator   string
+	Default     []int
+	Value       *[]int
+}
+
+// Factory to generate a new integer slice flag
+func NewIntSliceFlag(longflag string, shortflag string, required bool, defaultvalue []int, description string) IntSliceFlag {
+	flag := IntSliceFlag{
+		Longflag:    longflag,
+		Shortflag:   shortflag,
+		Description: description,
+		Required:    required,
+		Default:     defaultvalue,
+		Value:       new([]int),
+	}
+	*flag.Value = append([]int(nil), defaultvalue...)
+
+	return flag
+}
+
+// Restore the value to its default
+func (f *IntSliceFlag) reset() {
+	*f.Value = append([]int(nil), f.Default...)
+}
+
+// Put back a value taken from value() when a new one is rejected
+func (f *IntSliceFlag) restore(value interface{}) {
+	*f.Value = value.([]int)
+}
+
+// Copy the definition with its own value storage
+func (f *IntSliceFlag) clone() valueFlag {
+	flag := *f
+	flag.Value = new([]int)
+	flag.reset()
+	return &flag
+}
+
+// Convert and append a value from the command line, split by the separator if set
+func (f *IntSliceFlag) set(value string) error {
+	values := []string{value}
+	if f.Separator != "" {
+		values = strings.Split(value, f.Separator)
+	}
+	converted := make([]int, 0, len(values))
+	for _, v := range values {
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			return conversionError(err, "integer")
+		}
+		converted = append(converted, i)
+	}
+	*f.Value = append(*f.Value, converted...)
+	return nil
+}
+
+// Drop the default values before the first occurrence is appended
+func (f *IntSliceFlag) clear() {
+	*f.Value = nil
+}
+
+// Set the separator that splits a single value into several
+func (f *IntSliceFlag) setSeparator(separator string) {
+	f.Separator = separator
+}
+
+// Check if a required flag has no values
+func (f *IntSliceFlag) isMissing(given bool) bool {
+	return f.Required && len(*f.Value) == 0
+}
+
+// Get the converted value for validators
+func (f *IntSliceFlag) value() interface{} {
+	return *f.Value
+}
+
+// Get the name of the environment variable used as fallback
+func (f *IntSliceFlag) env() string {
+	return f.Env
+}
+
+// Set the name of the environment variable used as fallback
+func (f *IntSliceFlag) setEnv(name string) {
+	f.Env = name
+}
+
+// Collect the metadata used for help, documentation and completion
+func (f *IntSliceFlag) info() flagInfo {
+	defaults := make([]string, len(f.Default))
+	for i, v := range f.Default {
+		defaults[i] = strconv.Itoa(v)
+	}
+	return flagInfo{
+		longflag:     f.Longflag,
+		shortflag:    f.Shortflag,
+		description:  f.Description,
+		typename:     "ints",
+		defaultvalue: strings.Join(defaults, ", "),
+		env:          f.Env,
+		required:     f.Required,
+		repeatable:   true,
+		takesValue:   true,
+	}
+}
+
+// Generate the string for the long description
+func (f *IntSliceFlag) GetLongDescription() string {
+	return longDescription(f.info())
+}
+
+// Generate the string for a short description in the 'Usage:' line
+func (f *IntSliceFlag) GetShortDescription() string {
+	return shortDescription(f.info())
+}
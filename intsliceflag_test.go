@@ -0,0 +1,53 @@
+package argumentative
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewIntSliceFlag(t *testing.T) {
+	flag := NewIntSliceFlag("longname", "s", true, []int{1, 2}, "description")
+
+	if flag.Longflag != "longname" {
+		t.Errorf("Longflag assignment wrong, got [%s], want [%s]", flag.Longflag, "longname")
+	}
+
+	if !reflect.DeepEqual(*flag.Value, []int{1, 2}) {
+		t.Errorf("Assignment of default to value wrong, got [%v], want [%v]", *flag.Value, []int{1, 2})
+	}
+}
+
+func TestSetIntSlice(t *testing.T) {
+	flag := NewIntSliceFlag("longname", "s", false, nil, "description")
+	flag.setSeparator(",")
+	flag.set("1")
+	flag.set("2,3")
+	await := []int{1, 2, 3}
+
+	if !reflect.DeepEqual(*flag.Value, await) {
+		t.Errorf("Collecting values failed, got [%v], want [%v]", *flag.Value, await)
+	}
+
+	err := flag.set("4,x")
+	awaitErr := "expected integer"
+
+	if err == nil {
+		t.Errorf("No error found, got [%p], want pointer", err)
+	} else if err.Error() != awaitErr {
+		t.Errorf("Wrong error message, got [%s], want [%s]", err, awaitErr)
+	}
+
+	if !reflect.DeepEqual(*flag.Value, await) {
+		t.Errorf("Invalid value was partially collected, got [%v], want [%v]", *flag.Value, await)
+	}
+}
+
+func TestGetLongIntSliceDescription(t *testing.T) {
+	flag := NewIntSliceFlag("longname", "s", false, []int{1, 2}, "description")
+	result := flag.GetLongDescription()
+	await := "-s, --longname           description (Default: 1, 2)"
+
+	if result != await {
+		t.Errorf("Generation of long description failed, got [%s], want [%s]", result, await)
+	}
+}
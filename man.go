@@ -0,0 +1,157 @@
+package argumentative
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Write a man page in roff format for the program name with its description
+func (f *Flags) WriteManPage(w io.Writer, name string, description string) error {
+	_, err := io.WriteString(w, manPage(f, []string{name}, description))
+	return err
+}
+
+// Write the man page of the program and one for every subcommand like name-command.1 into dir
+func (f *Flags) WriteManPages(dir string, name string, description string) error {
+	return writeManPages(f, dir, []string{name}, description)
+}
+
+// Write the man page for a path of commands and recurse into its subcommands
+func writeManPages(f *Flags, dir string, path []string, description string) error {
+	page := manPage(f, path, description)
+	if err := os.WriteFile(filepath.Join(dir, strings.Join(path, "-")+".1"), []byte(page), 0o644); err != nil {
+		return err
+	}
+	for _, command := range f.commands {
+		subpath := append(append([]string(nil), path...), command.Name)
+		if err := writeManPages(command.Flags, dir, subpath, command.Description); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Escape text for roff, backslashes and hyphens would be interpreted otherwise
+func roffEscape(text string) string {
+	text = strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(text)
+	// Lines starting with a dot or an apostrophe would be taken as requests
+	if strings.HasPrefix(text, ".") || strings.HasPrefix(text, "'") {
+		text = `\&` + text
+	}
+	return text
+}
+
+// Generate the synopsis of a set of flags, switches first, then flags with values,
+// positional arguments and commands
+func (f *Flags) synopsis() string {
+	return strings.Join(f.synopsisParts(false), " ")
+}
+
+// Generate a man page for the flags reached by a path of commands
+func manPage(f *Flags, path []string, description string) string {
+	title := strings.Join(path, "-")
+
+	var b strings.Builder
+	b.WriteString(".TH " + roffEscape(strings.ToUpper(title)) + " 1 \"\" \"" + roffEscape(path[0]) + "\" \"User Commands\"\n")
+
+	b.WriteString(".SH NAME\n")
+	b.WriteString(roffEscape(title))
+	if description != "" {
+		b.WriteString(" \\- " + roffEscape(description))
+	}
+	b.WriteString("\n")
+
+	b.WriteString(".SH SYNOPSIS\n")
+	b.WriteString(".B " + roffEscape(strings.Join(path, " ")) + "\n")
+	if synopsis := f.synopsis(); synopsis != "" {
+		b.WriteString(roffEscape(synopsis) + "\n")
+	}
+
+	if description != "" {
+		b.WriteString(".SH DESCRIPTION\n")
+		b.WriteString(roffEscape(description) + "\n")
+	}
+
+	infos := f.flagInfos()
+	if len(infos) > 0 {
+		b.WriteString(".SH OPTIONS\n")
+		for _, info := range infos {
+			b.WriteString(".TP\n")
+			if info.shortflag != "" {
+				b.WriteString("\\fB" + roffEscape("-"+info.shortflag) + "\\fR, ")
+			}
+			b.WriteString("\\fB" + roffEscape("--"+negationPrefix(info)+info.longflag) + "\\fR")
+			if len(info.choices) > 0 {
+				b.WriteString(" \\fI" + roffEscape(choiceList(info.choices)) + "\\fR")
+			} else if info.takesValue {
+				b.WriteString(" \\fI" + roffEscape(strings.ToUpper(info.longflag)) + "\\fR")
+			}
+			b.WriteString("\n")
+			b.WriteString(roffEscape(manFlagText(info)) + "\n")
+		}
+	}
+
+	positionals := f.positionalInfos()
+	if len(positionals) > 0 {
+		b.WriteString(".SH ARGUMENTS\n")
+		for _, positional := range positionals {
+			b.WriteString(".TP\n")
+			b.WriteString("\\fI" + roffEscape(positionalNames(positional)) + "\\fR\n")
+			b.WriteString(roffEscape(strings.TrimSpace(positionalText(positional))) + "\n")
+		}
+	}
+
+	if len(f.commands) > 0 {
+		b.WriteString(".SH COMMANDS\n")
+		for _, command := range f.commands {
+			b.WriteString(".TP\n")
+			b.WriteString("\\fB" + roffEscape(command.Name) + "\\fR\n")
+			b.WriteString(roffEscape(command.Description) + "\n")
+		}
+	}
+
+	var environment []flagInfo
+	for _, info := range infos {
+		if info.env != "" {
+			environment = append(environment, info)
+		}
+	}
+	if len(environment) > 0 {
+		b.WriteString(".SH ENVIRONMENT\n")
+		for _, info := range environment {
+			b.WriteString(".TP\n")
+			b.WriteString(".B " + roffEscape(info.env) + "\n")
+			b.WriteString(roffEscape("Used for --"+info.longflag+" if it is not given on the command line.") + "\n")
+		}
+	}
+
+	b.WriteString(".SH EXIT STATUS\n")
+	b.WriteString(".TP\n.B 0\nSuccessful execution.\n")
+	b.WriteString(".TP\n.B 1\nInvalid command line arguments or failed execution.\n")
+
+	if len(f.commands) > 0 {
+		b.WriteString(".SH SEE ALSO\n")
+		for i, command := range f.commands {
+			if i > 0 {
+				b.WriteString(",\n")
+			}
+			b.WriteString(".BR " + roffEscape(title+"-"+command.Name) + " (1)")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Generate the description of a flag in a man page including its default value
+func manFlagText(info flagInfo) string {
+	text := info.description
+	if info.defaultvalue != "" {
+		text += " (Default: " + info.defaultvalue + ")"
+	}
+	if info.required {
+		text += " (Required)"
+	}
+	return strings.TrimSpace(text)
+}
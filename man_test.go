@@ -0,0 +1,50 @@
+package argumentative
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRoffEscape(t *testing.T) {
+	tests := map[string]string{
+		"--verbose":       `\-\-verbose`,
+		`C:\temp`:         `C:\etemp`,
+		".hidden file":    `\&.hidden file`,
+		"'quoted' text":   `\&'quoted' text`,
+		"plain text here": "plain text here",
+	}
+	for text, await := range tests {
+		if result := roffEscape(text); result != await {
+			t.Errorf("Escaping of [%s] failed, got [%s], want [%s]", text, result, await)
+		}
+	}
+}
+
+func TestWriteManPage(t *testing.T) {
+	flags := completionFlags()
+	flags.SetEnvPrefix("TOOL_")
+
+	var result bytes.Buffer
+	if err := flags.WriteManPage(&result, "tool", "A tool to deploy services"); err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+	assertGolden(t, "tool.1.golden", result.Bytes())
+}
+
+func TestWriteManPages(t *testing.T) {
+	dir := t.TempDir()
+	if err := completionFlags().WriteManPages(dir, "tool", "A tool to deploy services"); err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	for _, name := range []string{"tool.1", "tool-deploy.1", "tool-status.1"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("Man page %s not written, got [%s], want nil", name, err.Error())
+		}
+	}
+
+	result, _ := os.ReadFile(filepath.Join(dir, "tool-deploy.1"))
+	assertGolden(t, "tool-deploy.1.golden", result)
+}
@@ -0,0 +1,124 @@
+package argumentative
+
+import (
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Write a Markdown reference of the program name with its description and all subcommands
+func (f *Flags) WriteMarkdown(w io.Writer, name string, description string) error {
+	var b strings.Builder
+	writeMarkdown(&b, f, []string{name}, description, 1)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Write the Markdown section for a path of commands and recurse into its subcommands
+func writeMarkdown(b *strings.Builder, f *Flags, path []string, description string, level int) {
+	heading := strings.Repeat("#", level)
+	subheading := strings.Repeat("#", level+1)
+	title := strings.Join(path, " ")
+
+	b.WriteString("<a id=\"" + markdownAnchor(path) + "\"></a>\n")
+	b.WriteString(heading + " " + title + "\n\n")
+	if description != "" {
+		b.WriteString(description + "\n\n")
+	}
+
+	b.WriteString(subheading + " Synopsis\n\n")
+	b.WriteString("```\n" + strings.TrimSpace(title+" "+f.synopsis()) + "\n```\n\n")
+
+	if infos := f.flagInfos(); len(infos) > 0 {
+		b.WriteString(subheading + " Flags\n\n")
+		b.WriteString("| Name | Short | Type | Default | Required | Environment | Description |\n")
+		b.WriteString("|------|-------|------|---------|----------|-------------|-------------|\n")
+		for _, info := range infos {
+			cells := []string{
+				markdownCode("--" + negationPrefix(info) + info.longflag),
+				"",
+				info.typename,
+				markdownCode(info.defaultvalue),
+				"no",
+				markdownCode(info.env),
+				markdownEscape(info.description),
+			}
+			if info.shortflag != "" {
+				cells[1] = markdownCode("-" + info.shortflag)
+			}
+			if len(info.choices) > 0 {
+				cells[2] = markdownCode(choiceList(info.choices))
+			}
+			if info.required {
+				cells[4] = "yes"
+			}
+			b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+		}
+		b.WriteString("\n")
+	}
+
+	if positionals := f.positionalInfos(); len(positionals) > 0 {
+		b.WriteString(subheading + " Positional arguments\n\n")
+		b.WriteString("| Name | Values | Default | Description |\n")
+		b.WriteString("|------|--------|---------|-------------|\n")
+		for _, positional := range positionals {
+			cells := []string{
+				markdownCode(positionalNames(positional)),
+				markdownValues(positional.min, positional.max),
+				markdownCode(positional.defaultvalue),
+				markdownEscape(positional.description),
+			}
+			b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+		}
+		b.WriteString("\n")
+	}
+
+	if len(f.commands) > 0 {
+		b.WriteString(subheading + " Commands\n\n")
+		b.WriteString("| Name | Description |\n")
+		b.WriteString("|------|-------------|\n")
+		for _, command := range f.commands {
+			subpath := append(append([]string(nil), path...), command.Name)
+			b.WriteString("| [" + markdownCode(command.Name) + "](#" + markdownAnchor(subpath) + ") | " + markdownEscape(command.Description) + " |\n")
+		}
+		b.WriteString("\n")
+	}
+
+	for _, command := range f.commands {
+		subpath := append(append([]string(nil), path...), command.Name)
+		sublevel := level + 1
+		if sublevel > 5 {
+			sublevel = 5
+		}
+		writeMarkdown(b, command.Flags, subpath, command.Description, sublevel)
+	}
+}
+
+// Generate the anchor of a command section like "tool-deploy"
+func markdownAnchor(path []string) string {
+	return strings.ToLower(strings.Join(path, "-"))
+}
+
+// Escape pipes and line breaks that would break a table cell
+func markdownEscape(text string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(text)
+}
+
+// Format a table cell as inline code, an empty cell stays empty
+func markdownCode(text string) string {
+	if text == "" {
+		return ""
+	}
+	return "`" + markdownEscape(text) + "`"
+}
+
+// Format the number of values of a positional argument like "1", "0..1" or "1.."
+func markdownValues(min int, max int) string {
+	if min == max {
+		return strconv.Itoa(min)
+	}
+	if max < 0 {
+		return strconv.Itoa(min) + ".."
+	}
+	return strconv.Itoa(min) + ".." + strconv.Itoa(max)
+}
@@ -0,0 +1,36 @@
+package argumentative
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMarkdownValues(t *testing.T) {
+	tests := map[[2]int]string{{1, 1}: "1", {0, 1}: "0..1", {1, -1}: "1..", {0, -1}: "0..", {2, 2}: "2"}
+	for bounds, await := range tests {
+		if result := markdownValues(bounds[0], bounds[1]); result != await {
+			t.Errorf("Formatting of values %v failed, got [%s], want [%s]", bounds, result, await)
+		}
+	}
+}
+
+func TestMarkdownEscape(t *testing.T) {
+	result := markdownCode("json|yaml")
+	await := "`json\\|yaml`"
+
+	if result != await {
+		t.Errorf("Escaping of table cell failed, got [%s], want [%s]", result, await)
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	flags := completionFlags()
+	flags.SetEnvPrefix("TOOL_")
+	flags.getCommand("deploy").Flags.AddPositionalList("target", "*", []string{"all"}, "Targets to deploy to")
+
+	var result bytes.Buffer
+	if err := flags.WriteMarkdown(&result, "tool", "A tool to deploy services"); err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+	assertGolden(t, "tool.md.golden", result.Bytes())
+}
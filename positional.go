@@ -1,15 +1,13 @@
 package argumentative
 
-import (
-	"fmt"
-)
-
 // struct for a single configured positional argument
 type Positional struct {
 	Longflag    string
 	Description string
 	Required    bool
+	IgnoreCase  bool
 	Default     string
+	Choices     []string
 	Value       *string
 }
 
@@ -29,16 +27,71 @@ func NewPositional(longflag string, required bool, defaultvalue string, descript
 	return positional
 }
 
-// Generate the string for the long description
-func (f *Positional) GetLongDescription() string {
-	output := fmt.Sprintf("%-25s", f.Longflag)
-	if f.Description != "" {
-		output += f.Description
+// Restore the value to its default
+func (f *Positional) reset() {
+	*f.Value = f.Default
+}
+
+// Copy the definition with its own value storage
+func (f *Positional) clone() positionalArg {
+	positional := *f
+	positional.Value = new(string)
+	positional.reset()
+	return &positional
+}
+
+// Get the minimum and maximum number of values
+func (f *Positional) bounds() (int, int) {
+	if f.Required {
+		return 1, 1
+	}
+	return 0, 1
+}
+
+// Assign the value taken from the command line
+func (f *Positional) assign(values []string) {
+	*f.Value = values[0]
+}
+
+// Get the values for validators, none if it is empty
+func (f *Positional) values() []string {
+	if *f.Value == "" {
+		return nil
 	}
-	if f.Default != "" {
-		output += " (Default: " + f.Default + ")"
+	return []string{*f.Value}
+}
+
+// Check if a required positional argument has a value and the value is one of the choices
+func (f *Positional) validate() error {
+	if f.Required && *f.Value == "" {
+		return &MissingRequiredError{Kind: "positional argument", Name: f.Longflag}
 	}
-	return output
+	if len(f.Choices) > 0 && *f.Value != "" {
+		choice, err := matchChoice(*f.Value, f.Choices, f.IgnoreCase)
+		if err != nil {
+			return &InvalidValueError{Flag: f.Longflag, Value: *f.Value, Index: -1, Positional: true, Err: err}
+		}
+		*f.Value = choice
+	}
+	return nil
+}
+
+// Collect the metadata used for documentation and completion
+func (f *Positional) info() positionalInfo {
+	min, max := f.bounds()
+	return positionalInfo{
+		name:         f.Longflag,
+		description:  f.Description,
+		defaultvalue: f.Default,
+		min:          min,
+		max:          max,
+		choices:      f.Choices,
+	}
+}
+
+// Generate the string for the long description
+func (f *Positional) GetLongDescription() string {
+	return formatEntry(positionalNames(f.info()), positionalText(f.info()), defaultColumn, 0)
 }
 
 // Generate the string for a short description in the 'Usage:' line
@@ -47,9 +100,30 @@ func (f *Positional) GetShortDescription() string {
 	if !f.Required {
 		output += "["
 	}
-	output += f.Longflag
+	if len(f.Choices) > 0 {
+		output += choiceList(f.Choices)
+	} else {
+		output += f.Longflag
+	}
 	if !f.Required {
 		output += "]"
 	}
 	return output
 }
+
+// Generate the name of a positional argument in help with its choices like "mode {start,stop}"
+func positionalNames(info positionalInfo) string {
+	if len(info.choices) > 0 {
+		return info.name + " " + choiceList(info.choices)
+	}
+	return info.name
+}
+
+// Generate the help text of a positional argument with its default value
+func positionalText(info positionalInfo) string {
+	output := info.description
+	if info.defaultvalue != "" {
+		output += " (Default: " + info.defaultvalue + ")"
+	}
+	return output
+}
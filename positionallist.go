@@ -0,0 +1,127 @@
+package argumentative
+
+import (
+	"strconv"
+	"strings"
+)
+
+// struct for a single configured positional argument taking several values
+type PositionalList struct {
+	Longflag    string
+	Description string
+	Min         int
+	Max         int
+	Default     []string
+	Value       *[]string
+}
+
+// Factory to generate a new positional argument list, nargs is "?", "*", "+" or a number like "2"
+func NewPositionalList(longflag string, nargs string, defaultvalue []string, description string) PositionalList {
+	min, max := parseNargs(nargs)
+	positional := PositionalList{
+		Longflag:    longflag,
+		Description: description,
+		Min:         min,
+		Max:         max,
+		Default:     defaultvalue,
+		Value:       new([]string),
+	}
+	*positional.Value = append([]string(nil), defaultvalue...)
+
+	return positional
+}
+
+// Restore the value to its default
+func (f *PositionalList) reset() {
+	*f.Value = append([]string(nil), f.Default...)
+}
+
+// Copy the definition with its own value storage
+func (f *PositionalList) clone() positionalArg {
+	positional := *f
+	positional.Value = new([]string)
+	positional.reset()
+	return &positional
+}
+
+// Translate nargs into the minimum and maximum number of values, -1 is unlimited
+func parseNargs(nargs string) (int, int) {
+	switch nargs {
+	case "?":
+		return 0, 1
+	case "*":
+		return 0, -1
+	case "+":
+		return 1, -1
+	}
+	count, err := strconv.Atoi(nargs)
+	if err != nil || count < 1 {
+		panic("argumentative: invalid nargs " + strconv.Quote(nargs) + ", use \"?\", \"*\", \"+\" or a number")
+	}
+	return count, count
+}
+
+// Get the minimum and maximum number of values, -1 is unlimited
+func (f *PositionalList) bounds() (int, int) {
+	return f.Min, f.Max
+}
+
+// Assign the values taken from the command line
+func (f *PositionalList) assign(values []string) {
+	*f.Value = append([]string(nil), values...)
+}
+
+// Get the values for validators
+func (f *PositionalList) values() []string {
+	return *f.Value
+}
+
+// Check if the number of values is within the bounds
+func (f *PositionalList) validate() error {
+	if len(*f.Value) < f.Min || f.Max >= 0 && len(*f.Value) > f.Max {
+		return &PositionalCountError{Name: f.Longflag, Min: f.Min, Max: f.Max, Count: len(*f.Value)}
+	}
+	return nil
+}
+
+// Format a number of values like "1 value" or "2 values"
+func countValues(count int) string {
+	if count == 1 {
+		return "1 value"
+	}
+	return strconv.Itoa(count) + " values"
+}
+
+// Collect the metadata used for documentation and completion
+func (f *PositionalList) info() positionalInfo {
+	return positionalInfo{
+		name:         f.Longflag,
+		description:  f.Description,
+		defaultvalue: strings.Join(f.Default, ", "),
+		min:          f.Min,
+		max:          f.Max,
+	}
+}
+
+// Generate the string for the long description
+func (f *PositionalList) GetLongDescription() string {
+	return formatEntry(f.Longflag, positionalText(f.info()), defaultColumn, 0)
+}
+
+// Generate the string for a short description in the 'Usage:' line
+func (f *PositionalList) GetShortDescription() string {
+	output := ""
+	for i := 0; i < f.Min; i++ {
+		output += " " + f.Longflag
+	}
+	if f.Max < 0 {
+		if f.Min > 0 {
+			return output + "..."
+		}
+		return output + " [" + f.Longflag + "...]"
+	}
+	for i := f.Min; i < f.Max; i++ {
+		output += " [" + f.Longflag + "]"
+	}
+	return output
+}
@@ -0,0 +1,88 @@
+package argumentative
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewPositionalList(t *testing.T) {
+	flag := NewPositionalList("longname", "+", []string{"a", "b"}, "description")
+
+	if flag.Longflag != "longname" {
+		t.Errorf("Longflag assignment wrong, got [%s], want [%s]", flag.Longflag, "longname")
+	}
+
+	if flag.Min != 1 || flag.Max != -1 {
+		t.Errorf("Bounds assignment wrong, got [%d %d], want [%d %d]", flag.Min, flag.Max, 1, -1)
+	}
+
+	if !reflect.DeepEqual(*flag.Value, []string{"a", "b"}) {
+		t.Errorf("Assignment of default to value wrong, got [%v], want [%v]", *flag.Value, flag.Default)
+	}
+}
+
+func TestParseNargs(t *testing.T) {
+	tests := map[string][2]int{"?": {0, 1}, "*": {0, -1}, "+": {1, -1}, "3": {3, 3}}
+	for nargs, await := range tests {
+		min, max := parseNargs(nargs)
+		if min != await[0] || max != await[1] {
+			t.Errorf("Parsing nargs %s failed, got [%d %d], want [%d %d]", nargs, min, max, await[0], await[1])
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("No panic for invalid nargs")
+		}
+	}()
+	parseNargs("0")
+}
+
+func TestValidatePositionalList(t *testing.T) {
+	flag := NewPositionalList("longname", "+", nil, "description")
+	err := flag.validate()
+	await := "positional argument [longname] requires at least 1 value, got 0"
+
+	if err == nil {
+		t.Errorf("No error found, got [%p], want pointer", err)
+	} else if err.Error() != await {
+		t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
+	}
+
+	flag = NewPositionalList("longname", "2", []string{"a"}, "description")
+	err = flag.validate()
+	await = "positional argument [longname] requires 2 values, got 1"
+
+	if err == nil {
+		t.Errorf("No error found, got [%p], want pointer", err)
+	} else if err.Error() != await {
+		t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
+	}
+}
+
+func TestGetLongPositionalListDescription(t *testing.T) {
+	flag := NewPositionalList("longname", "*", []string{"a", "b"}, "description")
+	result := flag.GetLongDescription()
+	await := "longname                 description (Default: a, b)"
+
+	if result != await {
+		t.Errorf("Generation of long description failed, got [%s], want [%s]", result, await)
+	}
+}
+
+func TestGetShortPositionalListDescription(t *testing.T) {
+	tests := map[string]string{
+		"?": " [longname]",
+		"*": " [longname...]",
+		"+": " longname...",
+		"2": " longname longname",
+	}
+	for nargs, await := range tests {
+		flag := NewPositionalList("longname", nargs, nil, "description")
+		result := flag.GetShortDescription()
+
+		if result != await {
+			t.Errorf("Generation of short description for nargs %s failed, got [%s], want [%s]", nargs, result, await)
+		}
+	}
+}
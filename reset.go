@@ -0,0 +1,94 @@
+package argumentative
+
+// Restore every flag and positional argument of the set and its subcommands to its default and forget
+// what the last Parse found, Parse does this itself before reading the arguments
+func (f *Flags) Reset() {
+	for _, flag := range f.boolflags {
+		flag.reset()
+	}
+	for _, flag := range f.countflags {
+		flag.reset()
+	}
+	for _, flag := range f.valueflags {
+		flag.reset()
+	}
+	for _, positional := range f.positionals {
+		positional.reset()
+	}
+	for _, command := range f.commands {
+		command.Flags.Reset()
+	}
+	f.sources = make(map[string]Source)
+	f.argindexes = make(map[string][]int)
+	f.command = ""
+	f.rest = nil
+}
+
+// Copy the definitions of the set and its subcommands with fresh values at their defaults, parsing
+// the copy does not change the values of the original, nor the fields of a struct passed to Bind
+func (f *Flags) Clone() *Flags {
+	clone := *f.Flags()
+	clone.boolflags = make(map[string]BoolFlag, len(f.boolflags))
+	for name, flag := range f.boolflags {
+		clone.boolflags[name] = flag.clone()
+	}
+	clone.countflags = make(map[string]CountFlag, len(f.countflags))
+	for name, flag := range f.countflags {
+		clone.countflags[name] = flag.clone()
+	}
+	clone.valueflags = make(map[string]valueFlag, len(f.valueflags))
+	for name, flag := range f.valueflags {
+		clone.valueflags[name] = flag.clone()
+	}
+	clone.positionals = make([]positionalArg, 0, len(f.positionals))
+	for _, positional := range f.positionals {
+		clone.positionals = append(clone.positionals, positional.clone())
+	}
+	clone.commands = make([]Command, 0, len(f.commands))
+	for _, command := range f.commands {
+		command.Flags = command.Flags.Clone()
+		clone.commands = append(clone.commands, command)
+	}
+	clone.order = append([]string(nil), f.order...)
+	clone.shortflags = make(map[byte]string, len(f.shortflags))
+	for short, name := range f.shortflags {
+		clone.shortflags[short] = name
+	}
+	clone.pathflags = make(map[string]bool, len(f.pathflags))
+	for name, isPath := range f.pathflags {
+		clone.pathflags[name] = isPath
+	}
+	clone.validators = make(map[string][]Validator, len(f.validators))
+	for name, validators := range f.validators {
+		clone.validators[name] = append([]Validator(nil), validators...)
+	}
+	clone.groups = append([]flagGroup(nil), f.groups...)
+	clone.sources = make(map[string]Source)
+	clone.argindexes = make(map[string][]int)
+	clone.command = ""
+	clone.rest = nil
+	clone.config = nil
+	return &clone
+}
+
+// Get the current value of a flag or positional argument, like an int for int flags, []string for
+// repeatable flags and positional lists or the number of occurrences for count flags, a copy made
+// by Clone has no other way to read its values
+func (f *Flags) Value(name string) interface{} {
+	if flag, ok := f.valueflags[name]; ok {
+		return flag.value()
+	}
+	if flag, ok := f.boolflags[name]; ok {
+		return *flag.Value
+	}
+	if flag, ok := f.countflags[name]; ok {
+		return *flag.Value
+	}
+	switch positional := f.positional(name).(type) {
+	case *Positional:
+		return *positional.Value
+	case *PositionalList:
+		return *positional.Value
+	}
+	panic("argumentative: no flag or positional argument " + name)
+}
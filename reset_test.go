@@ -0,0 +1,147 @@
+package argumentative
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// Build a set with every kind of flag and positional argument and a way to read all its values
+func resetFlags() (*Flags, func() string) {
+	flags := &Flags{}
+	verbose := flags.Flags().AddBool("verbose", "v", "Verbose output")
+	color := flags.Flags().AddNegatableBool("color", "", true, "Colored output")
+	debug := flags.Flags().AddCount("debug", "d", 0, "Debug level")
+	output := flags.Flags().AddString("output", "o", false, "out.txt", "Output file")
+	port := flags.Flags().AddInt("port", "p", false, 80, "Port")
+	ratio := flags.Flags().AddFloat64("ratio", "r", false, 0.5, "Ratio")
+	format := flags.Flags().AddChoice("format", "f", false, "table", []string{"json", "table"}, "Format")
+	include := flags.Flags().AddStringSlice("include", "I", false, []string{"lib"}, "Include path")
+	ids := flags.Flags().AddIntSlice("id", "", false, []int{1}, "IDs")
+	source := flags.Flags().AddPositional("source", false, "src", "Source")
+	targets := flags.Flags().AddPositionalList("targets", "*", []string{"all"}, "Targets")
+
+	return flags, func() string {
+		return fmt.Sprint(*verbose, *color, *debug, *output, *port, *ratio, *format, *include, *ids, *source, *targets,
+			flags.Rest(), flags.sources)
+	}
+}
+
+func TestRepeatedParse(t *testing.T) {
+	inputs := [][]string{
+		{"tool", "-vdd", "--no-color", "-o", "x", "-p", "8080", "-r", "1", "-f", "json", "-I", "a", "--id", "5", "in", "t1", "t2", "--", "rest"},
+		{"tool"},
+		{"tool", "-I", "b", "-I", "c", "in"},
+		{"tool", "-p", "http"},
+		{"tool", "-d", "in", "t1"},
+		{"tool", "--color"},
+	}
+	flags, values := resetFlags()
+	for _, args := range inputs {
+		fresh, freshValues := resetFlags()
+		err := flags.Parse(args)
+		freshErr := fresh.Parse(args)
+		if fmt.Sprint(err) != fmt.Sprint(freshErr) || values() != freshValues() {
+			t.Errorf("Parse of %v differs from a fresh set, got [%v %s], want [%v %s]", args, err, values(), freshErr, freshValues())
+		}
+	}
+}
+
+func TestReset(t *testing.T) {
+	flags, values := resetFlags()
+	_, await := resetFlags()
+
+	if err := flags.Parse([]string{"tool", "-vd", "-o", "x", "-I", "a", "in", "t1"}); err != nil {
+		t.Fatalf("Error found, got [%s], want nil", err)
+	}
+	flags.Reset()
+
+	if values() != await() {
+		t.Errorf("Wrong values after Reset, got [%s], want [%s]", values(), await())
+	}
+	if flags.IsSet("output") {
+		t.Errorf("Source kept after Reset, got [%s], want [%s]", flags.Source("output"), SourceDefault)
+	}
+}
+
+func TestClone(t *testing.T) {
+	flags, values := resetFlags()
+	flags.AddValidator("port", func(raw string, value interface{}) error {
+		if value.(int) == 1 {
+			return fmt.Errorf("not 1")
+		}
+		return nil
+	})
+	if err := flags.Parse([]string{"tool", "-o", "x"}); err != nil {
+		t.Fatalf("Error found, got [%s], want nil", err)
+	}
+	before := values()
+
+	clone := flags.Clone()
+	if clone.IsSet("output") || clone.Value("output") != "out.txt" {
+		t.Errorf("Clone did not start at the defaults, got [%s]", clone.Source("output"))
+	}
+	if err := clone.Parse([]string{"tool", "-vp", "8080", "-I", "a", "in", "t1"}); err != nil {
+		t.Fatalf("Error found, got [%s], want nil", err)
+	}
+	if values() != before {
+		t.Errorf("Parsing the clone changed the original, got [%s], want [%s]", values(), before)
+	}
+	result := fmt.Sprintf("%v %v %v %v %v %v", clone.Value("verbose"), clone.Value("debug"), clone.Value("port"), clone.Value("include"), clone.Value("source"), clone.Value("targets"))
+	if result != "true 0 8080 [a] in [t1]" {
+		t.Errorf("Wrong values of clone, got [%s], want [%s]", result, "true 0 8080 [a] in [t1]")
+	}
+
+	err := clone.Parse([]string{"tool", "-p", "1"})
+	await := `invalid value "1" for --port: not 1`
+	if err == nil || err.Error() != await {
+		t.Errorf("Validators not cloned, got [%v], want [%s]", err, await)
+	}
+}
+
+func TestResetCommands(t *testing.T) {
+	flags := &Flags{}
+	deploy := flags.Flags().AddCommand("deploy", "Deploy")
+	env := deploy.AddString("env", "e", false, "dev", "Environment")
+	status := flags.Flags().AddCommand("status", "Status")
+	all := status.AddBool("all", "a", "All services")
+
+	if err := flags.Parse([]string{"tool", "deploy", "-e", "prod"}); err != nil || *env != "prod" {
+		t.Fatalf("Error found, got [%v %s], want [nil prod]", err, *env)
+	}
+	if err := flags.Parse([]string{"tool", "status", "-a"}); err != nil || !*all {
+		t.Fatalf("Error found, got [%v %t], want [nil true]", err, *all)
+	}
+	if *env != "dev" || deploy.IsSet("env") || flags.Command() != "status" {
+		t.Errorf("Values of previous command were kept, got [%s %s %s], want [dev default status]", *env, deploy.Source("env"), flags.Command())
+	}
+
+	clone := flags.Clone()
+	if err := clone.Parse([]string{"tool", "deploy", "-e", "test"}); err != nil {
+		t.Fatalf("Error found, got [%s], want nil", err)
+	}
+	if *env != "dev" || !*all || clone.commands[0].Flags.Value("env") != "test" {
+		t.Errorf("Clone shares the values of subcommands, got [%s %t]", *env, *all)
+	}
+}
+
+func TestCloneBind(t *testing.T) {
+	var options struct {
+		Name  string   `arg:"name,short=n,default=web"`
+		Files []string `arg:"files,positional"`
+	}
+	flags := &Flags{}
+	flags.Flags().Bind(&options)
+
+	clone := flags.Clone()
+	if err := clone.Parse([]string{"tool", "-n", "db", "a"}); err != nil {
+		t.Fatalf("Error found, got [%s], want nil", err)
+	}
+	if options.Name != "web" || len(options.Files) != 0 || clone.Value("name") != "db" {
+		t.Errorf("Parsing the clone changed the bound struct, got [%s %v], want [web []]", options.Name, options.Files)
+	}
+
+	if err := flags.Parse([]string{"tool", "a", "b"}); err != nil || !reflect.DeepEqual(options.Files, []string{"a", "b"}) {
+		t.Errorf("Wrong values of original, got [%v %v], want [nil [a b]]", err, options.Files)
+	}
+}
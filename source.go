@@ -0,0 +1,91 @@
+package argumentative
+
+// Where the value of a flag or positional argument came from
+type Source int
+
+// Sources of values, from lowest to highest precedence except for SourcePrompt which is set after Parse
+const (
+	SourceDefault Source = iota
+	SourceConfig
+	SourceEnv
+	SourceCLI
+	SourcePrompt
+)
+
+// Get the name of the source like "cli"
+func (s Source) String() string {
+	switch s {
+	case SourceConfig:
+		return "config"
+	case SourceEnv:
+		return "env"
+	case SourceCLI:
+		return "cli"
+	case SourcePrompt:
+		return "prompt"
+	}
+	return "default"
+}
+
+// Check if a flag or positional argument got a value from the command line, environment, config file
+// or Set during the last Parse, even if it equals the default
+func (f *Flags) IsSet(name string) bool {
+	return f.sources[name] != SourceDefault
+}
+
+// Get where the value of a flag or positional argument came from, SourceDefault if it was not set
+func (f *Flags) Source(name string) Source {
+	return f.sources[name]
+}
+
+// Set a flag to a value obtained after Parse, like by asking the user for a missing required flag,
+// the value is converted and validated like on the command line and Validate checks the result.
+// Count flags take the number of occurrences.
+func (f *Flags) Set(name string, value string) error {
+	if flag, ok := f.valueflags[name]; ok {
+		// A rejected value leaves the previous one in place
+		previous := flag.value()
+		// The value replaces all values of repeatable flags
+		if slice, ok := flag.(sliceFlag); ok {
+			slice.clear()
+		}
+		if err := f.setValue(flag, name, value, -1); err != nil {
+			flag.restore(previous)
+			return err
+		}
+	} else if flag, ok := f.boolflags[name]; ok {
+		converted, err := parseBool(value)
+		if err != nil {
+			return &InvalidValueError{Flag: name, Value: value, Index: -1, Err: conversionError(err, "boolean")}
+		}
+		if err := f.runValidators(name, value, converted); err != nil {
+			return &InvalidValueError{Flag: name, Value: value, Index: -1, Err: err}
+		}
+		*flag.Value = converted
+	} else if flag, ok := f.countflags[name]; ok {
+		previous := *flag.Value
+		err := flag.set(value)
+		if err == nil {
+			err = f.runValidators(name, value, *flag.Value)
+		}
+		if err != nil {
+			*flag.Value = previous
+			return &InvalidValueError{Flag: name, Value: value, Index: -1, Err: err}
+		}
+	} else if f.positional(name) != nil {
+		panic("argumentative: positional argument " + name + " can not be set, only flags")
+	} else {
+		panic("argumentative: no flag --" + name + " to set")
+	}
+	f.sources[name] = SourcePrompt
+	return nil
+}
+
+// Let a required string flag be given as an empty value like --name ""
+func (f *Flags) SetAllowEmpty(longflag string) {
+	flag, ok := f.valueflags[longflag].(*StringFlag)
+	if !ok {
+		panic("argumentative: --" + longflag + " is not a string flag")
+	}
+	flag.AllowEmpty = true
+}
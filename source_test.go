@@ -0,0 +1,173 @@
+package argumentative
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSource(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "tool.conf")
+	if err := os.WriteFile(config, []byte("level = 3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TEST_SOURCE_USER", "admin")
+
+	flags := &Flags{}
+	flags.Flags().AddString("config", "c", false, config, "Config file")
+	flags.Flags().AddString("host", "", false, "localhost", "Host")
+	flags.Flags().AddInt("port", "p", false, 80, "Port")
+	flags.Flags().AddString("user", "u", false, "", "User")
+	flags.Flags().AddInt("level", "l", false, 0, "Level")
+	flags.Flags().AddBool("verbose", "v", "Verbose output")
+	flags.Flags().AddPositional("target", false, "", "Target")
+	flags.SetEnv("user", "TEST_SOURCE_USER")
+	flags.SetConfigFlag("config")
+
+	if err := flags.Parse([]string{"tool", "--port", "80", "web"}); err != nil {
+		t.Fatalf("Error found, got [%s], want nil", err)
+	}
+
+	tests := map[string]Source{
+		"host":    SourceDefault,
+		"port":    SourceCLI,
+		"user":    SourceEnv,
+		"level":   SourceConfig,
+		"verbose": SourceDefault,
+		"target":  SourceCLI,
+		"unknown": SourceDefault,
+	}
+	for name, await := range tests {
+		if result := flags.Source(name); result != await {
+			t.Errorf("Wrong source of %s, got [%s], want [%s]", name, result, await)
+		}
+		if flags.IsSet(name) != (await != SourceDefault) {
+			t.Errorf("Wrong IsSet for %s, got [%t], want [%t]", name, flags.IsSet(name), await != SourceDefault)
+		}
+	}
+
+	if err := flags.Parse([]string{"tool"}); err != nil || flags.IsSet("port") || flags.IsSet("target") {
+		t.Errorf("Sources of previous parse were kept, got [%v %s %s]", err, flags.Source("port"), flags.Source("target"))
+	}
+}
+
+func TestSet(t *testing.T) {
+	flags := &Flags{}
+	name := flags.Flags().AddString("name", "n", true, "", "Name")
+	port := flags.Flags().AddInt("port", "p", false, 80, "Port")
+	tags := flags.Flags().AddStringSlice("tag", "t", false, nil, "Tags")
+	verbose := flags.Flags().AddBool("verbose", "v", "Verbose output")
+
+	err := flags.Parse([]string{"tool", "-t", "a"})
+	await := "required flag --name missing"
+	if err == nil || err.Error() != await {
+		t.Errorf("Wrong error message, got [%v], want [%s]", err, await)
+	}
+
+	for name, value := range map[string]string{"name": "web", "tag": "b", "verbose": "yes"} {
+		if err := flags.Set(name, value); err != nil {
+			t.Errorf("Error setting %s, got [%s], want nil", name, err)
+		}
+	}
+	if err := flags.Validate(); err != nil {
+		t.Errorf("Error found, got [%s], want nil", err)
+	}
+	if *name != "web" || !*verbose || !reflect.DeepEqual(*tags, []string{"b"}) {
+		t.Errorf("Wrong values, got [%s %t %v], want [web true [b]]", *name, *verbose, *tags)
+	}
+	if flags.Source("name") != SourcePrompt {
+		t.Errorf("Wrong source, got [%s], want [%s]", flags.Source("name"), SourcePrompt)
+	}
+
+	err = flags.Set("port", "http")
+	await = `invalid value "http" for --port: expected integer`
+	if err == nil || err.Error() != await || *port != 80 {
+		t.Errorf("Wrong error message, got [%v], want [%s]", err, await)
+	}
+}
+
+func TestSetRejected(t *testing.T) {
+	flags := &Flags{}
+	name := flags.Flags().AddString("name", "n", false, "", "Name")
+	tags := flags.Flags().AddStringSlice("tag", "t", false, nil, "Tags")
+	flags.AddValidator("name", NotEmpty())
+	flags.AddValidator("tag", MatchRegexp("^z"))
+
+	if err := flags.Parse([]string{"tool", "-n", "web", "-t", "zz"}); err != nil {
+		t.Fatalf("Error found, got [%s], want nil", err)
+	}
+
+	if err := flags.Set("name", ""); err == nil || *name != "web" || flags.Source("name") != SourceCLI {
+		t.Errorf("Rejected value kept, got [%v %s %s], want [error web cli]", err, *name, flags.Source("name"))
+	}
+
+	if err := flags.Set("tag", "bad"); err == nil || !reflect.DeepEqual(*tags, []string{"zz"}) {
+		t.Errorf("Rejected values kept, got [%v %v], want [error [zz]]", err, *tags)
+	}
+}
+
+func TestSetSwitches(t *testing.T) {
+	flags := &Flags{}
+	verbose := flags.Flags().AddCount("verbose", "v", 3, "Verbose output")
+	force := flags.Flags().AddBool("force", "f", "Force")
+	flags.Flags().AddPositional("target", false, "", "Target")
+	flags.AddValidator("force", func(raw string, value interface{}) error {
+		if value.(bool) {
+			return errors.New("not allowed")
+		}
+		return nil
+	})
+
+	if err := flags.Parse([]string{"tool", "-v"}); err != nil {
+		t.Fatalf("Error found, got [%s], want nil", err)
+	}
+	if err := flags.Set("verbose", "2"); err != nil || *verbose != 2 || flags.Source("verbose") != SourcePrompt {
+		t.Errorf("Count not set, got [%v %d %s], want [nil 2 prompt]", err, *verbose, flags.Source("verbose"))
+	}
+
+	if err := flags.Set("verbose", "4"); err != nil || *verbose != 3 {
+		t.Errorf("Count not capped, got [%v %d], want [nil 3]", err, *verbose)
+	}
+
+	err := flags.Set("verbose", "-1")
+	await := `invalid value "-1" for --verbose: must not be negative`
+	if err == nil || err.Error() != await || *verbose != 3 {
+		t.Errorf("Wrong error message, got [%v %d], want [%s 3]", err, *verbose, await)
+	}
+
+	err = flags.Set("force", "yes")
+	await = `invalid value "yes" for --force: not allowed`
+	if err == nil || err.Error() != await || *force {
+		t.Errorf("Bool value not validated, got [%v %t], want [%s false]", err, *force, await)
+	}
+
+	defer func() {
+		if result := recover(); result != "argumentative: positional argument target can not be set, only flags" {
+			t.Errorf("Wrong panic message, got [%v]", result)
+		}
+	}()
+	flags.Set("target", "x")
+}
+
+func TestAllowEmpty(t *testing.T) {
+	flags := &Flags{}
+	name := flags.Flags().AddString("name", "n", true, "", "Name")
+
+	err := flags.Parse([]string{"tool", "--name", ""})
+	await := "required flag --name missing"
+	if err == nil || err.Error() != await {
+		t.Errorf("Wrong error message, got [%v], want [%s]", err, await)
+	}
+
+	flags.SetAllowEmpty("name")
+	if err := flags.Parse([]string{"tool", "--name", ""}); err != nil || *name != "" {
+		t.Errorf("Empty value rejected, got [%v], want nil", err)
+	}
+
+	err = flags.Parse([]string{"tool"})
+	if err == nil || err.Error() != await {
+		t.Errorf("Missing flag accepted, got [%v], want [%s]", err, await)
+	}
+}
@@ -1,15 +1,13 @@
 package argumentative
 
-import (
-	"fmt"
-)
-
 // struct for a single configured flag
 type StringFlag struct {
 	Longflag    string
 	Shortflag   string
 	Description string
+	Env         string
 	Required    bool
+	AllowEmpty  bool
 	Default     string
 	Value       *string
 }
@@ -31,37 +29,71 @@ func NewStringFlag(longflag string, shortflag string, required bool, defaultvalu
 	return flag
 }
 
-// Generate the string for the long description
-func (f *StringFlag) GetLongDescription() string {
-	flagnames := ""
-	if f.Shortflag != "" {
-		flagnames += "-" + f.Shortflag + ", "
-	}
-	flagnames += "--" + f.Longflag
-	output := fmt.Sprintf("%-25s", flagnames)
-	if f.Description != "" {
-		output += f.Description
-	}
-	if f.Default != "" {
-		output += " (Default: " + f.Default + ")"
+// Restore the value to its default
+func (f *StringFlag) reset() {
+	*f.Value = f.Default
+}
+
+// Put back a value taken from value() when a new one is rejected
+func (f *StringFlag) restore(value interface{}) {
+	*f.Value = value.(string)
+}
+
+// Copy the definition with its own value storage
+func (f *StringFlag) clone() valueFlag {
+	flag := *f
+	flag.Value = new(string)
+	flag.reset()
+	return &flag
+}
+
+// Assign a value from the command line
+func (f *StringFlag) set(value string) error {
+	*f.Value = value
+	return nil
+}
+
+// Check if a required flag has no value, an empty one counts if it was given and empty values are allowed
+func (f *StringFlag) isMissing(given bool) bool {
+	return f.Required && *f.Value == "" && !(f.AllowEmpty && given)
+}
+
+// Get the converted value for validators
+func (f *StringFlag) value() interface{} {
+	return *f.Value
+}
+
+// Get the name of the environment variable used as fallback
+func (f *StringFlag) env() string {
+	return f.Env
+}
+
+// Set the name of the environment variable used as fallback
+func (f *StringFlag) setEnv(name string) {
+	f.Env = name
+}
+
+// Collect the metadata used for help, documentation and completion
+func (f *StringFlag) info() flagInfo {
+	return flagInfo{
+		longflag:     f.Longflag,
+		shortflag:    f.Shortflag,
+		description:  f.Description,
+		typename:     "string",
+		defaultvalue: f.Default,
+		env:          f.Env,
+		required:     f.Required,
+		repeatable:   false,
+		takesValue:   true,
 	}
+}
 
-	return output
+// Generate the string for the long description
+func (f *StringFlag) GetLongDescription() string {
+	return longDescription(f.info())
 }
 
 // Generate the string for a short description in the 'Usage:' line
 func (f *StringFlag) GetShortDescription() string {
-	output := " "
-	if !f.Required {
-		output += "["
-	}
-	if f.Shortflag != "" {
-		output += "-" + f.Shortflag
-	} else {
-		output += "--" + f.Longflag
-	}
-	if !f.Required {
-		output += "]"
-	}
-	return output
+	return shortDescription(f.info())
 }
@@ -63,7 +63,7 @@ func TestGetLongDescription(t *testing.T) {
 func TestGetShortDescription(t *testing.T) {
 	flag := NewStringFlag("longname", "s", true, "default", "description")
 	result := flag.GetShortDescription()
-	await := " -s" // @todo: remove space
+	await := " -s LONGNAME" // @todo: remove space
 
 	if result != await {
 		t.Errorf("Generation of short description failed, got [%s], want [%s]", result, await)
@@ -71,7 +71,7 @@ func TestGetShortDescription(t *testing.T) {
 
 	flag = NewStringFlag("longname", "s", false, "", "description")
 	result = flag.GetShortDescription()
-	await = " [-s]" // @todo remove space
+	await = " [-s LONGNAME]" // @todo remove space
 
 	if result != await {
 		t.Errorf("Generation of short description no required failed, got [%s], want [%s]", result, await)
@@ -79,7 +79,7 @@ func TestGetShortDescription(t *testing.T) {
 
 	flag = NewStringFlag("longname", "", false, "", "description")
 	result = flag.GetShortDescription()
-	await = " [--longname]" // @todo remove space
+	await = " [--longname LONGNAME]" // @todo remove space
 
 	if result != await {
 		t.Errorf("Generation of short description no short name failed, got [%s], want [%s]", result, await)
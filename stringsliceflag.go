@@ -0,0 +1,115 @@
+package argumentative
+
+import (
+	"strings"
+)
+
+// struct for a single configured flag that collects every occurrence
+type StringSliceFlag struct {
+	Longflag    string
+	Shortflag   string
+	Description string
+	Env         string
+	Required    bool
+	Separator   string
+	Default     []string
+	Value       *[]string
+}
+
+// Factory to generate a new string slice flag
+func NewStringSliceFlag(longflag string, shortflag string, required bool, defaultvalue []string, description string) StringSliceFlag {
+	flag := StringSliceFlag{
+		Longflag:    longflag,
+		Shortflag:   shortflag,
+		Description: description,
+		Required:    required,
+		Default:     defaultvalue,
+		Value:       new([]string),
+	}
+	*flag.Value = append([]string(nil), defaultvalue...)
+
+	return flag
+}
+
+// Restore the value to its default
+func (f *StringSliceFlag) reset() {
+	*f.Value = append([]string(nil), f.Default...)
+}
+
+// Put back a value taken from value() when a new one is rejected
+func (f *StringSliceFlag) restore(value interface{}) {
+	*f.Value = value.([]string)
+}
+
+// Copy the definition with its own value storage
+func (f *StringSliceFlag) clone() valueFlag {
+	flag := *f
+	flag.Value = new([]string)
+	flag.reset()
+	return &flag
+}
+
+// Append a value from the command line, split by the separator if set
+func (f *StringSliceFlag) set(value string) error {
+	if f.Separator != "" {
+		*f.Value = append(*f.Value, strings.Split(value, f.Separator)...)
+	} else {
+		*f.Value = append(*f.Value, value)
+	}
+	return nil
+}
+
+// Drop the default values before the first occurrence is appended
+func (f *StringSliceFlag) clear() {
+	*f.Value = nil
+}
+
+// Set the separator that splits a single value into several
+func (f *StringSliceFlag) setSeparator(separator string) {
+	f.Separator = separator
+}
+
+// Check if a required flag has no values
+func (f *StringSliceFlag) isMissing(given bool) bool {
+	return f.Required && len(*f.Value) == 0
+}
+
+// Get the converted value for validators
+func (f *StringSliceFlag) value() interface{} {
+	return *f.Value
+}
+
+// Get the name of the environment variable used as fallback
+func (f *StringSliceFlag) env() string {
+	return f.Env
+}
+
+// Set the name of the environment variable used as fallback
+func (f *StringSliceFlag) setEnv(name string) {
+	f.Env = name
+}
+
+// Collect the metadata used for help, documentation and completion
+func (f *StringSliceFlag) info() flagInfo {
+	return flagInfo{
+		longflag:     f.Longflag,
+		shortflag:    f.Shortflag,
+		description:  f.Description,
+		typename:     "strings",
+		defaultvalue: strings.Join(f.Default, ", "),
+		env:          f.Env,
+		required:     f.Required,
+		repeatable:   true,
+		takesValue:   true,
+	}
+}
+
+// Generate the string for the long description
+func (f *StringSliceFlag) GetLongDescription() string {
+	return longDescription(f.info())
+}
+
+// Generate the string for a short description in the 'Usage:' line
+func (f *StringSliceFlag) GetShortDescription() string {
+	return shortDescription(f.info())
+}
@@ -0,0 +1,75 @@
+package argumentative
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewStringSliceFlag(t *testing.T) {
+	defaults := []string{"a", "b"}
+	flag := NewStringSliceFlag("longname", "s", true, defaults, "description")
+
+	if flag.Longflag != "longname" {
+		t.Errorf("Longflag assignment wrong, got [%s], want [%s]", flag.Longflag, "longname")
+	}
+
+	if flag.Shortflag != "s" {
+		t.Errorf("Shortflag assignment wrong, got [%s], want [%s]", flag.Shortflag, "s")
+	}
+
+	if !reflect.DeepEqual(*flag.Value, defaults) {
+		t.Errorf("Assignment of default to value wrong, got [%v], want [%v]", *flag.Value, defaults)
+	}
+
+	(*flag.Value)[0] = "changed"
+	if defaults[0] != "a" {
+		t.Errorf("Value shares storage with default, got [%v], want [%v]", defaults, []string{"a", "b"})
+	}
+}
+
+func TestSetStringSlice(t *testing.T) {
+	flag := NewStringSliceFlag("longname", "s", false, nil, "description")
+	flag.set("a")
+	flag.set("b,c")
+	await := []string{"a", "b,c"}
+
+	if !reflect.DeepEqual(*flag.Value, await) {
+		t.Errorf("Collecting values failed, got [%v], want [%v]", *flag.Value, await)
+	}
+
+	flag.setSeparator(",")
+	flag.set("d,e")
+	await = []string{"a", "b,c", "d", "e"}
+
+	if !reflect.DeepEqual(*flag.Value, await) {
+		t.Errorf("Splitting values failed, got [%v], want [%v]", *flag.Value, await)
+	}
+}
+
+func TestGetLongStringSliceDescription(t *testing.T) {
+	flag := NewStringSliceFlag("longname", "s", false, []string{"a", "b"}, "description")
+	result := flag.GetLongDescription()
+	await := "-s, --longname           description (Default: a, b)"
+
+	if result != await {
+		t.Errorf("Generation of long description failed, got [%s], want [%s]", result, await)
+	}
+}
+
+func TestGetShortStringSliceDescription(t *testing.T) {
+	flag := NewStringSliceFlag("longname", "s", false, nil, "description")
+	result := flag.GetShortDescription()
+	await := " [-s LONGNAME]..." // @todo: remove space
+
+	if result != await {
+		t.Errorf("Generation of short description failed, got [%s], want [%s]", result, await)
+	}
+
+	flag = NewStringSliceFlag("longname", "", true, nil, "description")
+	result = flag.GetShortDescription()
+	await = " --longname LONGNAME..." // @todo remove space
+
+	if result != await {
+		t.Errorf("Generation of short description required failed, got [%s], want [%s]", result, await)
+	}
+}
@@ -0,0 +1,144 @@
+package argumentative
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	// Edit distance up to which unknown names get suggestions by default
+	defaultDistance = 2
+	// Number of suggestions given at most
+	maxSuggestions = 3
+)
+
+// Set the edit distance up to which unknown flags and commands get suggestions, 0 turns them off,
+// the setting applies to the subcommands as well
+func (f *Flags) SetSuggestionDistance(distance int) {
+	if distance < 0 {
+		panic("argumentative: suggestion distance must not be negative")
+	}
+	f.distance = distance
+	for _, command := range f.commands {
+		command.Flags.SetSuggestionDistance(distance)
+	}
+}
+
+// struct for a name that is similar to an unknown one
+type suggestion struct {
+	name     string
+	distance int
+}
+
+// Find the flags similar to an unknown flag name given without dashes
+func (f *Flags) suggestFlags(input string) []string {
+	var candidates []suggestion
+	for _, name := range f.order {
+		candidates = f.addCandidate(candidates, input, name, "--"+name)
+		if short := f.shortName(name); short != "" {
+			candidates = f.addCandidate(candidates, input, short, "-"+short)
+		}
+	}
+	return f.closest(input, candidates)
+}
+
+// Find the commands similar to an unknown command
+func (f *Flags) suggestCommands(input string) []string {
+	var candidates []suggestion
+	for _, command := range f.commands {
+		candidates = f.addCandidate(candidates, input, command.Name, command.Name)
+	}
+	return f.closest(input, candidates)
+}
+
+// Add a name to the candidates if it is within the suggestion distance of the input. The edit
+// distance is at least the difference in length, so long inputs are rejected without comparing them.
+func (f *Flags) addCandidate(candidates []suggestion, input string, name string, display string) []suggestion {
+	if f.distance == 0 || len(input)-len(name) > f.distance || len(name)-len(input) > f.distance {
+		return candidates
+	}
+	if d := distance(input, name); d <= f.distance {
+		candidates = append(candidates, suggestion{display, d})
+	}
+	return candidates
+}
+
+// Get the short name of a flag, empty if it has none
+func (f *Flags) shortName(longflag string) string {
+	for short, name := range f.shortflags {
+		if name == longflag {
+			return string(short)
+		}
+	}
+	return ""
+}
+
+// Pick the closest candidates, closest first. A distance has to be
+// smaller than the input, so single letters do not suggest every other single letter.
+func (f *Flags) closest(input string, candidates []suggestion) []string {
+	var matches []suggestion
+	for _, candidate := range candidates {
+		if candidate.distance < len(input) {
+			matches = append(matches, candidate)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].distance < matches[j].distance
+	})
+	var names []string
+	for _, match := range matches {
+		if len(names) == maxSuggestions {
+			break
+		}
+		names = append(names, match.name)
+	}
+	return names
+}
+
+// Compute the edit distance of two names, swapping two neighbouring letters counts as one edit
+func distance(a string, b string) int {
+	a = strings.ToLower(a)
+	b = strings.ToLower(b)
+	rows := make([][]int, len(a)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(b)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			rows[i][j] = minimum(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				rows[i][j] = minimum(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return rows[len(a)][len(b)]
+}
+
+// Get the smallest of some numbers
+func minimum(first int, others ...int) int {
+	for _, other := range others {
+		if other < first {
+			first = other
+		}
+	}
+	return first
+}
+
+// Generate the hint appended to an error like ", did you mean --verbose?"
+func didYouMean(suggestions []string) string {
+	switch len(suggestions) {
+	case 0:
+		return ""
+	case 1:
+		return ", did you mean " + suggestions[0] + "?"
+	}
+	return ", did you mean " + strings.Join(suggestions[:len(suggestions)-1], ", ") + " or " + suggestions[len(suggestions)-1] + "?"
+}
@@ -0,0 +1,157 @@
+package argumentative
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a     string
+		b     string
+		await int
+	}{
+		{"verbose", "verbose", 0},
+		{"verbsoe", "verbose", 1},
+		{"verbos", "verbose", 1},
+		{"Verbose", "verbose", 0},
+		{"outptu-fil", "output-file", 2},
+		{"", "abc", 3},
+	}
+	for _, test := range tests {
+		if result := distance(test.a, test.b); result != test.await {
+			t.Errorf("Wrong distance of %s and %s, got [%d], want [%d]", test.a, test.b, result, test.await)
+		}
+	}
+}
+
+func TestSuggestFlags(t *testing.T) {
+	flags := &Flags{}
+	flags.Flags().AddBool("verbose", "v", "Verbose output")
+	flags.Flags().AddBool("version", "", "Show version")
+	flags.Flags().AddString("output", "o", false, "", "Output file")
+
+	err := flags.Parse([]string{"tool", "--verbsoe"})
+	await := "unknown flag --verbsoe, did you mean --verbose?"
+	if err == nil || err.Error() != await {
+		t.Errorf("Wrong suggestion, got [%v], want [%s]", err, await)
+	}
+
+	var unknown *UnknownFlagError
+	if !errors.As(err, &unknown) || !reflect.DeepEqual(unknown.Suggestions, []string{"--verbose"}) {
+		t.Errorf("Suggestions not available on the error, got [%v]", err)
+	}
+
+	err = flags.Parse([]string{"tool", "--verbon"})
+	await = "unknown flag --verbon, did you mean --verbose or --version?"
+	if err == nil || err.Error() != await {
+		t.Errorf("Wrong suggestions, got [%v], want [%s]", err, await)
+	}
+
+	err = flags.Parse([]string{"tool", "--o"})
+	await = "unknown flag --o, did you mean -o?"
+	if err == nil || err.Error() != await {
+		t.Errorf("Short flag not suggested, got [%v], want [%s]", err, await)
+	}
+
+	err = flags.Parse([]string{"tool", "-verbsoe"})
+	await = "unknown flag -e, did you mean --verbose?"
+	if err == nil || err.Error() != await {
+		t.Errorf("Long flag not suggested for short flags, got [%v], want [%s]", err, await)
+	}
+
+	err = flags.Parse([]string{"tool", "--xyzzy"})
+	await = "unknown flag --xyzzy"
+	if err == nil || err.Error() != await {
+		t.Errorf("Nonsense got a suggestion, got [%v], want [%s]", err, await)
+	}
+
+	err = flags.Parse([]string{"tool", "-x"})
+	await = "unknown flag -x"
+	if err == nil || err.Error() != await {
+		t.Errorf("Single letter got a suggestion, got [%v], want [%s]", err, await)
+	}
+}
+
+func TestSuggestCommands(t *testing.T) {
+	flags := &Flags{}
+	flags.Flags().AddCommand("deploy", "Deploy a service")
+	flags.Flags().AddCommand("status", "Show status")
+
+	err := flags.Parse([]string{"tool", "delpoy"})
+	await := "unknown command delpoy, did you mean deploy?"
+	if err == nil || err.Error() != await {
+		t.Errorf("Wrong suggestion, got [%v], want [%s]", err, await)
+	}
+
+	var unknown *UnknownCommandError
+	if !errors.As(err, &unknown) || unknown.Command != "delpoy" || unknown.Index != 1 {
+		t.Errorf("No UnknownCommandError found, got [%v]", err)
+	}
+}
+
+func TestSuggestionDistance(t *testing.T) {
+	flags := &Flags{}
+	flags.Flags().AddBool("verbose", "v", "Verbose output")
+
+	flags.SetSuggestionDistance(0)
+	err := flags.Parse([]string{"tool", "--verbsoe"})
+	await := "unknown flag --verbsoe"
+	if err == nil || err.Error() != await {
+		t.Errorf("Suggestions not turned off, got [%v], want [%s]", err, await)
+	}
+
+	flags.SetSuggestionDistance(3)
+	err = flags.Parse([]string{"tool", "--vrbse"})
+	await = "unknown flag --vrbse, did you mean --verbose?"
+	if err == nil || err.Error() != await {
+		t.Errorf("Larger distance not used, got [%v], want [%s]", err, await)
+	}
+}
+
+func TestSuggestionDistanceInCommands(t *testing.T) {
+	flags := &Flags{}
+	flags.Flags().SetSuggestionDistance(0)
+	deploy := flags.Flags().AddCommand("deploy", "Deploy")
+	deploy.AddBool("verbose", "v", "Verbose output")
+
+	err := flags.Parse([]string{"tool", "deploy", "--verbsoe"})
+	await := "unknown flag --verbsoe"
+	if err == nil || err.Error() != await {
+		t.Errorf("Suggestions not turned off in subcommand, got [%v], want [%s]", err, await)
+	}
+
+	flags.SetSuggestionDistance(2)
+	err = flags.Parse([]string{"tool", "deploy", "--verbsoe"})
+	await = "unknown flag --verbsoe, did you mean --verbose?"
+	if err == nil || err.Error() != await {
+		t.Errorf("Distance not applied to subcommand, got [%v], want [%s]", err, await)
+	}
+}
+
+func TestSuggestLongCluster(t *testing.T) {
+	flags := &Flags{}
+	flags.Flags().AddBool("verbose", "v", "Verbose output")
+	flags.Flags().AddString("output", "o", false, "", "Output file")
+	flags.SetAllErrors(true)
+
+	err := flags.Parse([]string{"tool", "-verbsoe"})
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok || len(joined.Unwrap()) != 4 {
+		t.Fatalf("Wrong errors for cluster, got [%v]", err)
+	}
+	for _, err := range joined.Unwrap() {
+		if !strings.HasSuffix(err.Error(), ", did you mean --verbose?") {
+			t.Errorf("Wrong suggestion in cluster, got [%s]", err)
+		}
+	}
+
+	// Far longer than any name, no edit distance is computed at all
+	err = flags.Parse([]string{"tool", "--" + strings.Repeat("x", 100000)})
+	var unknown *UnknownFlagError
+	if !errors.As(err, &unknown) || len(unknown.Suggestions) != 0 {
+		t.Errorf("Suggestions for long input, got [%v]", unknown.Suggestions)
+	}
+}
@@ -0,0 +1,108 @@
+package argumentative
+
+import (
+	"strconv"
+)
+
+// struct for a single configured unsigned integer flag
+type UintFlag struct {
+	Longflag    string
+	Shortflag   string
+	Description string
+	Env         string
+	Required    bool
+	Default     uint
+	Value       *uint
+}
+
+// Factory to generate a new unsigned integer flag
+func NewUintFlag(longflag string, shortflag string, required bool, defaultvalue uint, description string) UintFlag {
+	flag := UintFlag{
+		Longflag:    longflag,
+		Shortflag:   shortflag,
+		Description: description,
+		Required:    required,
+		Default:     defaultvalue,
+		Value:       new(uint),
+	}
+	*flag.Value = defaultvalue
+
+	return flag
+}
+
+// Restore the value to its default
+func (f *UintFlag) reset() {
+	*f.Value = f.Default
+}
+
+// Put back a value taken from value() when a new one is rejected
+func (f *UintFlag) restore(value interface{}) {
+	*f.Value = value.(uint)
+}
+
+// Copy the definition with its own value storage
+func (f *UintFlag) clone() valueFlag {
+	flag := *f
+	flag.Value = new(uint)
+	flag.reset()
+	return &flag
+}
+
+// Convert and assign a value from the command line
+func (f *UintFlag) set(value string) error {
+	converted, err := strconv.ParseUint(value, 10, strconv.IntSize)
+	if err != nil {
+		return conversionError(err, "unsigned integer")
+	}
+	*f.Value = uint(converted)
+	return nil
+}
+
+// Check if a required flag was neither given nor has a default
+func (f *UintFlag) isMissing(given bool) bool {
+	return f.Required && !given && *f.Value == 0
+}
+
+// Get the converted value for validators
+func (f *UintFlag) value() interface{} {
+	return *f.Value
+}
+
+// Get the name of the environment variable used as fallback
+func (f *UintFlag) env() string {
+	return f.Env
+}
+
+// Set the name of the environment variable used as fallback
+func (f *UintFlag) setEnv(name string) {
+	f.Env = name
+}
+
+// Collect the metadata used for help, documentation and completion
+func (f *UintFlag) info() flagInfo {
+	defaultvalue := ""
+	if f.Default != 0 {
+		defaultvalue = strconv.FormatUint(uint64(f.Default), 10)
+	}
+	return flagInfo{
+		longflag:     f.Longflag,
+		shortflag:    f.Shortflag,
+		description:  f.Description,
+		typename:     "uint",
+		defaultvalue: defaultvalue,
+		env:          f.Env,
+		required:     f.Required,
+		repeatable:   false,
+		takesValue:   true,
+	}
+}
+
+// Generate the string for the long description
+func (f *UintFlag) GetLongDescription() string {
+	return longDescription(f.info())
+}
+
+// Generate the string for a short description in the 'Usage:' line
+func (f *UintFlag) GetShortDescription() string {
+	return shortDescription(f.info())
+}
@@ -0,0 +1,50 @@
+package argumentative
+
+import "testing"
+
+func TestNewUintFlag(t *testing.T) {
+	flag := NewUintFlag("longname", "s", true, 8080, "description")
+
+	if flag.Longflag != "longname" {
+		t.Errorf("Longflag assignment wrong, got [%s], want [%s]", flag.Longflag, "longname")
+	}
+
+	if flag.Default != 8080 {
+		t.Errorf("Default assignment wrong, got [%d], want [%d]", flag.Default, 8080)
+	}
+
+	if *flag.Value != 8080 {
+		t.Errorf("Assignment of default to value wrong, got [%d], want [%d]", *flag.Value, flag.Default)
+	}
+}
+
+func TestSetUint(t *testing.T) {
+	flag := NewUintFlag("longname", "s", false, 0, "description")
+
+	if err := flag.set("443"); err != nil {
+		t.Errorf("Error found, got [%s], want nil", err.Error())
+	}
+
+	if *flag.Value != 443 {
+		t.Errorf("Conversion of value wrong, got [%d], want [%d]", *flag.Value, 443)
+	}
+
+	err := flag.set("-1")
+	await := "expected unsigned integer"
+
+	if err == nil {
+		t.Errorf("No error found, got [%p], want pointer", err)
+	} else if err.Error() != await {
+		t.Errorf("Wrong error message, got [%s], want [%s]", err, await)
+	}
+}
+
+func TestGetLongUintDescription(t *testing.T) {
+	flag := NewUintFlag("longname", "s", false, 8080, "description")
+	result := flag.GetLongDescription()
+	await := "-s, --longname           description (Default: 8080)"
+
+	if result != await {
+		t.Errorf("Generation of long description failed, got [%s], want [%s]", result, await)
+	}
+}
@@ -0,0 +1,172 @@
+package argumentative
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"unicode/utf8"
+)
+
+// Function that checks a value after its conversion, raw is the value as given and value the converted
+// one, like an int for int flags or all values collected so far for repeatable flags
+type Validator func(raw string, value interface{}) error
+
+// Add validators to a flag or positional argument, they run in order during Parse
+func (f *Flags) AddValidator(name string, validators ...Validator) {
+	if !f.hasFlag(name) && f.positional(name) == nil {
+		panic("argumentative: no flag or positional argument " + name + " to validate")
+	}
+	f.validators[name] = append(f.validators[name], validators...)
+}
+
+// Get a positional argument by name
+func (f *Flags) positional(name string) positionalArg {
+	for _, positional := range f.positionals {
+		if positional.info().name == name {
+			return positional
+		}
+	}
+	return nil
+}
+
+// Run the validators of a flag or positional argument, stop at the first error
+func (f *Flags) runValidators(name string, raw string, value interface{}) error {
+	for _, validator := range f.validators[name] {
+		if err := validator(raw, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get the strings a built-in validator checks, strings and lists of strings are checked
+// as converted, all other values as given
+func validatorStrings(raw string, value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	}
+	return []string{raw}
+}
+
+// Validator for values matching a regular expression, an invalid expression panics
+func MatchRegexp(pattern string) Validator {
+	expression := regexp.MustCompile(pattern)
+	return func(raw string, value interface{}) error {
+		for _, s := range validatorStrings(raw, value) {
+			if !expression.MatchString(s) {
+				return fmt.Errorf("must match %s", pattern)
+			}
+		}
+		return nil
+	}
+}
+
+// Validator for values that are not empty
+func NotEmpty() Validator {
+	return func(raw string, value interface{}) error {
+		for _, s := range validatorStrings(raw, value) {
+			if s == "" {
+				return errors.New("must not be empty")
+			}
+		}
+		return nil
+	}
+}
+
+// Validator for values with a length in characters from min to max, a max of -1 is unlimited
+func LengthBetween(min int, max int) Validator {
+	return func(raw string, value interface{}) error {
+		for _, s := range validatorStrings(raw, value) {
+			length := utf8.RuneCountInString(s)
+			if length < min {
+				return fmt.Errorf("must be at least %d characters long", min)
+			}
+			if max >= 0 && length > max {
+				return fmt.Errorf("must be at most %d characters long", max)
+			}
+		}
+		return nil
+	}
+}
+
+// Validator for paths of existing files
+func FileExists() Validator {
+	return func(raw string, value interface{}) error {
+		for _, path := range validatorStrings(raw, value) {
+			info, err := os.Stat(path)
+			if err != nil {
+				return fmt.Errorf("file %s does not exist", path)
+			}
+			if info.IsDir() {
+				return fmt.Errorf("%s is a directory, not a file", path)
+			}
+		}
+		return nil
+	}
+}
+
+// Validator for paths of existing directories
+func DirExists() Validator {
+	return func(raw string, value interface{}) error {
+		for _, path := range validatorStrings(raw, value) {
+			info, err := os.Stat(path)
+			if err != nil {
+				return fmt.Errorf("directory %s does not exist", path)
+			}
+			if !info.IsDir() {
+				return fmt.Errorf("%s is not a directory", path)
+			}
+		}
+		return nil
+	}
+}
+
+// Validator for paths that can be written, an existing file or directory or a new file
+// in an existing directory
+func Writable() Validator {
+	return func(raw string, value interface{}) error {
+		for _, path := range validatorStrings(raw, value) {
+			if !isWritable(path) {
+				return fmt.Errorf("%s is not writable", path)
+			}
+		}
+		return nil
+	}
+}
+
+// Check if a path can be written by trying it without changing existing files
+func isWritable(path string) bool {
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		// A new file needs a writable directory
+		return isWritableDir(filepath.Dir(path))
+	}
+	if err != nil {
+		return false
+	}
+	if info.IsDir() {
+		return isWritableDir(path)
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return false
+	}
+	file.Close()
+	return true
+}
+
+// Check if files can be created in a directory
+func isWritableDir(dir string) bool {
+	file, err := os.CreateTemp(dir, ".argumentative-*")
+	if err != nil {
+		return false
+	}
+	file.Close()
+	os.Remove(file.Name())
+	return true
+}
@@ -0,0 +1,163 @@
+package argumentative
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAddValidator(t *testing.T) {
+	flags := &Flags{}
+	port := flags.Flags().AddInt("port", "p", false, 80, "Port")
+	flags.Flags().AddPositional("name", false, "", "Name")
+	flags.AddValidator("port", func(raw string, value interface{}) error {
+		if value.(int) < 1024 {
+			return errors.New("must be at least 1024")
+		}
+		return nil
+	})
+	flags.AddValidator("name", NotEmpty(), MatchRegexp(`^[a-z]+$`))
+
+	if err := flags.Parse([]string{"tool", "--port", "8080", "web"}); err != nil || *port != 8080 {
+		t.Errorf("Valid values rejected, got [%v], want nil", err)
+	}
+
+	err := flags.Parse([]string{"tool", "--port", "443"})
+	await := `invalid value "443" for --port: must be at least 1024`
+	var invalid *InvalidValueError
+	if err == nil || err.Error() != await {
+		t.Errorf("Wrong error message, got [%v], want [%s]", err, await)
+	} else if !errors.As(err, &invalid) || invalid.Index != 1 {
+		t.Errorf("No InvalidValueError with index, got [%v]", err)
+	}
+
+	err = flags.Parse([]string{"tool", "Web"})
+	await = `invalid value "Web" for [name]: must match ^[a-z]+$`
+	if err == nil || err.Error() != await {
+		t.Errorf("Wrong error message for positional argument, got [%v], want [%s]", err, await)
+	}
+
+	t.Setenv("TEST_VALIDATOR_PORT", "22")
+	flags.SetEnv("port", "TEST_VALIDATOR_PORT")
+	err = flags.Parse([]string{"tool"})
+	await = `invalid value "22" for --port from environment TEST_VALIDATOR_PORT: must be at least 1024`
+	if err == nil || err.Error() != await {
+		t.Errorf("Value from environment not validated, got [%v], want [%s]", err, await)
+	}
+}
+
+func TestPositionalDefaultNotValidated(t *testing.T) {
+	flags := &Flags{}
+	flags.Flags().AddPositional("in", false, "/nonexistent/default", "Input file")
+	flags.AddValidator("in", FileExists())
+
+	if err := flags.Parse([]string{"tool"}); err != nil {
+		t.Errorf("Default validated, got [%s], want nil", err)
+	}
+
+	err := flags.Parse([]string{"tool", "/nonexistent/given"})
+	await := `invalid value "/nonexistent/given" for [in]: file /nonexistent/given does not exist`
+	if err == nil || err.Error() != await {
+		t.Errorf("Wrong error message, got [%v], want [%s]", err, await)
+	}
+}
+
+func TestSwitchValidator(t *testing.T) {
+	flags := &Flags{}
+	flags.Flags().AddCount("verbose", "v", 0, "Verbose output")
+	flags.AddValidator("verbose", func(raw string, value interface{}) error {
+		if value.(int) > 2 {
+			return errors.New("at most twice")
+		}
+		return nil
+	})
+
+	if err := flags.Parse([]string{"tool", "-vv"}); err != nil {
+		t.Errorf("Valid count rejected, got [%v], want nil", err)
+	}
+
+	err := flags.Parse([]string{"tool", "-vvv"})
+	await := `invalid value "3" for --verbose: at most twice`
+	if err == nil || err.Error() != await {
+		t.Errorf("Wrong error message, got [%v], want [%s]", err, await)
+	}
+}
+
+func TestAddValidatorPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("No panic for unknown flag")
+		}
+	}()
+	flags := &Flags{}
+	flags.Flags().AddValidator("unknown", NotEmpty())
+}
+
+func TestStringValidators(t *testing.T) {
+	tests := []struct {
+		validator Validator
+		value     interface{}
+		await     string
+	}{
+		{MatchRegexp(`^\d+$`), "123", ""},
+		{MatchRegexp(`^\d+$`), "12a", `must match ^\d+$`},
+		{MatchRegexp(`^\d+$`), []string{"1", "a"}, `must match ^\d+$`},
+		{NotEmpty(), "x", ""},
+		{NotEmpty(), "", "must not be empty"},
+		{LengthBetween(2, 4), "abc", ""},
+		{LengthBetween(2, 4), "a", "must be at least 2 characters long"},
+		{LengthBetween(2, 4), "abcde", "must be at most 4 characters long"},
+		{LengthBetween(2, -1), "abcdefgh", ""},
+		{LengthBetween(1, 2), "äö", ""},
+	}
+	for _, test := range tests {
+		result := ""
+		if err := test.validator("", test.value); err != nil {
+			result = err.Error()
+		}
+		if result != test.await {
+			t.Errorf("Wrong validation of %v, got [%s], want [%s]", test.value, result, test.await)
+		}
+	}
+}
+
+func TestPathValidators(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.txt")
+	if err := os.WriteFile(file, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "missing", "file.txt")
+
+	tests := []struct {
+		validator Validator
+		value     string
+		await     string
+	}{
+		{FileExists(), file, ""},
+		{FileExists(), dir, dir + " is a directory, not a file"},
+		{FileExists(), missing, "file " + missing + " does not exist"},
+		{DirExists(), dir, ""},
+		{DirExists(), file, file + " is not a directory"},
+		{DirExists(), missing, "directory " + missing + " does not exist"},
+		{Writable(), file, ""},
+		{Writable(), dir, ""},
+		{Writable(), filepath.Join(dir, "new.txt"), ""},
+		{Writable(), missing, missing + " is not writable"},
+	}
+	for _, test := range tests {
+		result := ""
+		if err := test.validator(test.value, test.value); err != nil {
+			result = err.Error()
+		}
+		if result != test.await {
+			t.Errorf("Wrong validation of %s, got [%s], want [%s]", test.value, result, test.await)
+		}
+	}
+
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 || strings.HasPrefix(entries[0].Name(), ".argumentative") {
+		t.Errorf("Writable left files behind, got [%v]", entries)
+	}
+}
@@ -0,0 +1,125 @@
+package argumentative
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// interface for all flag types that take a parameter value
+type valueFlag interface {
+	set(value string) error
+	isMissing(given bool) bool
+	value() interface{}
+	restore(value interface{})
+	env() string
+	setEnv(name string)
+	reset()
+	clone() valueFlag
+	info() flagInfo
+	GetLongDescription() string
+	GetShortDescription() string
+}
+
+// interface for flag types that collect every occurrence
+type sliceFlag interface {
+	clear()
+	setSeparator(separator string)
+}
+
+// interface for all kinds of positional arguments
+type positionalArg interface {
+	bounds() (int, int)
+	assign(values []string)
+	values() []string
+	validate() error
+	reset()
+	clone() positionalArg
+	info() positionalInfo
+	GetLongDescription() string
+	GetShortDescription() string
+}
+
+// Generate the long description shared by all flags
+func longDescription(info flagInfo) string {
+	return formatEntry(flagNames(info), flagText(info), defaultColumn, 0)
+}
+
+// Generate the names of a flag like "-s, --long"
+func flagNames(info flagInfo) string {
+	names := "--" + negationPrefix(info) + info.longflag
+	if info.shortflag != "" {
+		names = "-" + info.shortflag + ", " + names
+	}
+	if len(info.choices) > 0 {
+		names += " " + choiceList(info.choices)
+	}
+	return names
+}
+
+// Generate the "[no-]" shown in front of the long name of negatable flags
+func negationPrefix(info flagInfo) string {
+	if info.negatable {
+		return "[no-]"
+	}
+	return ""
+}
+
+// Generate the help text of a flag with its default value and environment variable
+func flagText(info flagInfo) string {
+	output := info.description
+	if info.defaultvalue != "" {
+		output += " (Default: " + info.defaultvalue + ")"
+	}
+	if info.env != "" {
+		output += " (Env: " + info.env + ")"
+	}
+	return output
+}
+
+// Generate the short description shared by all flags
+func shortDescription(info flagInfo) string {
+	output := " "
+	if !info.required {
+		output += "["
+	}
+	output += flagUsage(info)
+	if !info.required {
+		output += "]"
+	}
+	if info.repeatable {
+		output += "..."
+	}
+	return output
+}
+
+// Generate the usage of a flag with its value like "-o OUTPUT", the short name is preferred
+func flagUsage(info flagInfo) string {
+	usage := "--" + negationPrefix(info) + info.longflag
+	if info.shortflag != "" {
+		usage = "-" + info.shortflag
+	}
+	if placeholder := valuePlaceholder(info); placeholder != "" {
+		usage += " " + placeholder
+	}
+	return usage
+}
+
+// Generate the placeholder for the value of a flag like "OUTPUT" or "{json,yaml}", empty for switches
+func valuePlaceholder(info flagInfo) string {
+	if len(info.choices) > 0 {
+		return choiceList(info.choices)
+	}
+	if info.takesValue {
+		return strings.ToUpper(info.longflag)
+	}
+	return ""
+}
+
+// Translate a strconv error into a readable reason
+func conversionError(err error, expected string) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return errors.New("value out of range")
+	}
+	return errors.New("expected " + expected)
+}